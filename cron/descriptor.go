@@ -0,0 +1,81 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+// descriptors maps the predefined schedules to the cron expression they stand for.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// every is the descriptor for fixed interval schedules.
+const every = "@every"
+
+// ParseDescriptor parses one of the predefined schedules.
+// The descriptors stand for the cron expression of the same schedule and are returned as *schedule.Timer,
+// except @every which is returned as *schedule.Interval.
+//
+//	@yearly (or @annually)  once a year, at midnight of January 1st
+//	@monthly                once a month, at midnight of the first day
+//	@weekly                 once a week, at midnight between Saturday and Sunday
+//	@daily (or @midnight)   once a day, at midnight
+//	@hourly                 once an hour, at the beginning of the hour
+//	@every <duration>       at a fixed interval, eg: "@every 1h30m"
+// The duration of @every is written in time.ParseDuration syntax.
+//
+// eg:
+//	...
+//	s, err := cron.ParseDescriptor("@every 90s")   // will run every minute and a half.
+//	...
+func ParseDescriptor(desc string) (schedule.Scheduler, error) {
+	toks := split(desc)
+	if len(toks) == 0 {
+		return nil, &ParseError{Spec: desc, Column: 1, Msg: "empty descriptor"}
+	}
+
+	name := strings.ToLower(toks[0].text)
+	if name == every {
+		if len(toks) != 2 {
+			return nil, &ParseError{Spec: desc, Column: toks[0].col, Msg: "expected a single duration after @every"}
+		}
+		return parseEvery(desc, toks[1])
+	}
+
+	expr, ok := descriptors[name]
+	if !ok {
+		return nil, &ParseError{Spec: desc, Column: toks[0].col, Msg: fmt.Sprintf("unknown descriptor %q", toks[0].text)}
+	}
+	if len(toks) > 1 {
+		return nil, &ParseError{Spec: desc, Column: toks[1].col, Msg: fmt.Sprintf("unexpected %q after %s", toks[1].text, name)}
+	}
+	return Parse(expr)
+}
+
+// parseEvery converts the duration of @every to an interval based scheduler.
+func parseEvery(desc string, tok token) (schedule.Scheduler, error) {
+	d, err := time.ParseDuration(tok.text)
+	if err != nil {
+		return nil, &ParseError{Spec: desc, Column: tok.col, Msg: fmt.Sprintf("invalid duration %q", tok.text)}
+	}
+	if d <= 0 {
+		return nil, &ParseError{Spec: desc, Column: tok.col, Msg: fmt.Sprintf("duration %s must be positive", d)}
+	}
+
+	i := schedule.ByFreq(true)
+	i.AddHour(int(d / time.Hour)).
+		AddMinute(int(d % time.Hour / time.Minute)).
+		AddSecond(int(d % time.Minute / time.Second)).
+		AddNsec(int(d % time.Second))
+	return i, nil
+}
@@ -0,0 +1,134 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+func TestParseDescriptor(t *testing.T) {
+	clk := schedule.NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		desc    string
+		want    string
+		wantErr *ParseError
+	}{
+		{
+			name: "hourly",
+			desc: "@hourly",
+			want: "0 0 * * * *",
+		}, {
+			name: "daily",
+			desc: "@daily",
+			want: "0 0 0 * * *",
+		}, {
+			name: "midnight",
+			desc: "@midnight",
+			want: "0 0 0 * * *",
+		}, {
+			name: "weekly",
+			desc: "@weekly",
+			want: "0 0 0 * * 0",
+		}, {
+			name: "monthly",
+			desc: "@monthly",
+			want: "0 0 0 1 * *",
+		}, {
+			name: "yearly",
+			desc: "@YEARLY",
+			want: "0 0 0 1 1 *",
+		}, {
+			name:    "unknown",
+			desc:    "@fortnightly",
+			wantErr: &ParseError{Spec: "@fortnightly", Column: 1, Msg: `unknown descriptor "@fortnightly"`},
+		}, {
+			name:    "trailing field",
+			desc:    "@daily 5",
+			wantErr: &ParseError{Spec: "@daily 5", Column: 8, Msg: `unexpected "5" after @daily`},
+		}, {
+			name:    "every without duration",
+			desc:    "@every",
+			wantErr: &ParseError{Spec: "@every", Column: 1, Msg: "expected a single duration after @every"},
+		}, {
+			name:    "every invalid duration",
+			desc:    "@every 90",
+			wantErr: &ParseError{Spec: "@every 90", Column: 8, Msg: `invalid duration "90"`},
+		}, {
+			name:    "every negative duration",
+			desc:    "@every -1m",
+			wantErr: &ParseError{Spec: "@every -1m", Column: 8, Msg: "duration -1m0s must be positive"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDescriptor(tt.desc)
+			if tt.wantErr != nil {
+				var perr *ParseError
+				if !errors.As(err, &perr) || !reflect.DeepEqual(perr, tt.wantErr) {
+					t.Errorf("ParseDescriptor() error = %#v, wantErr %#v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ParseDescriptor() error = %v", err)
+				return
+			}
+			want, _ := Parse(tt.want)
+			if g, w := got.(*schedule.Timer).SetClock(clk), want.(*schedule.Timer).SetClock(clk); !reflect.DeepEqual(g, w) {
+				t.Errorf("ParseDescriptor() = %+v, want %+v", g, w)
+			}
+		})
+	}
+}
+
+func TestParseDescriptor_every(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{
+			name: "seconds",
+			desc: "@every 90s",
+			want: "0yrs 0months 0weeks 0days 0hrs 1mins 30secs 0nsecs -> next execution in 0s",
+		}, {
+			name: "mixed units",
+			desc: "@every 26h3m4.5s",
+			want: "0yrs 0months 0weeks 0days 26hrs 3mins 4secs 500000000nsecs -> next execution in 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.desc)
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+			i, ok := got.(*schedule.Interval)
+			if !ok {
+				t.Errorf("Parse() = %T, want *schedule.Interval", got)
+				return
+			}
+			if i.String() != tt.want {
+				t.Errorf("Parse() = %v, want %v", i.String(), tt.want)
+			}
+			if _, err := i.Next(); err != nil {
+				t.Errorf("Interval.Next() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParse_descriptor(t *testing.T) {
+	s, err := Parse(" @daily")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, ok := s.(*schedule.Timer); !ok {
+		t.Errorf("Parse() = %T, want *schedule.Timer", s)
+	}
+}
@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+// hashItem is a field written as H, derived from the hash of a key, eg: the name of the job.
+type hashItem struct {
+	b        bounds
+	from, to int
+	step     int // 0 when the field runs once within the range.
+	col      int
+}
+
+// ParseWithKey parses a cron expression as Parse does, accepting the H of Jenkins in any field.
+// H stands for a value of the field derived from the hash of the key, so the same key always gives the same
+// schedule while the schedules of different keys are spread over the field:
+//	H          a single value of the field
+//	H(0-29)    a single value within the range
+//	H/15       every 15th value, starting from a value within the first 15
+//	H(0-29)/10 every 10th value within the range, starting from a value within the first 10
+// H must be the only item of its field.
+//
+// eg:
+//	...
+//	s, err := cron.ParseWithKey("H H(0-5) * * *", "backup")   // will run once a day, at night, at a time which only depends on "backup".
+//	...
+func ParseWithKey(expr, key string) (schedule.Scheduler, error) {
+	if strings.HasPrefix(strings.TrimSpace(expr), "@") {
+		return ParseDescriptor(expr)
+	}
+
+	s, err := parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.hashes) > 0 && key == "" {
+		h := s.hashes[0]
+		return nil, &ParseError{Spec: expr, Field: h.b.name, Column: h.col, Msg: "H needs a key to be derived from, see ParseWithKey"}
+	}
+	t := s.timer()
+	for _, h := range s.hashes {
+		setHash(t, h)
+	}
+	t.SetHashKey(key)
+	return t, nil
+}
+
+// parseHash parses a field written as H, H(a-b), H/n or H(a-b)/n.
+func parseHash(tok token, b bounds) (hashItem, *ParseError) {
+	fail := func(col int, format string, args ...interface{}) (hashItem, *ParseError) {
+		return hashItem{}, &ParseError{Field: b.name, Column: col, Msg: fmt.Sprintf(format, args...)}
+	}
+	if strings.Contains(tok.text, ",") {
+		return fail(tok.col, "H must be the only item of the field")
+	}
+
+	// sunday is hashed as 0 only, so it is not twice as likely as the other days.
+	if b.name == days.name {
+		b.max = 6
+	}
+	h := hashItem{b: b, from: b.min, to: b.max, col: tok.col}
+
+	rest := tok.text[1:]
+	col := tok.col + 1
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return fail(col, "missing ) after H range")
+		}
+		first, last, ok := strings.Cut(rest[1:end], "-")
+		if !ok {
+			return fail(col+1, "H range %q must be written as a-b", rest[1:end])
+		}
+		var err *ParseError
+		if h.from, err = parseValue(token{text: first, col: col + 1}, b); err != nil {
+			return hashItem{}, err
+		}
+		if h.to, err = parseValue(token{text: last, col: col + len(first) + 2}, b); err != nil {
+			return hashItem{}, err
+		}
+		if h.from > h.to {
+			return fail(col+1, "range start %d is beyond range end %d", h.from, h.to)
+		}
+		rest, col = rest[end+1:], col+end+1
+	}
+
+	switch {
+	case rest == "":
+	case strings.HasPrefix(rest, "/"):
+		n, err := strconv.Atoi(rest[1:])
+		if err != nil || n <= 0 {
+			return fail(col+1, "step %q must be a positive number", rest[1:])
+		}
+		h.step = n
+	default:
+		return fail(col, "unexpected %q after H", rest)
+	}
+	return h, nil
+}
+
+// setHash sets the field of the timer to be derived from the hash key.
+func setHash(t *schedule.Timer, h hashItem) {
+	var step []int
+	if h.step > 0 {
+		step = []int{h.step}
+	}
+	switch h.b.name {
+	case seconds.name:
+		t.SetSecondHash(h.from, h.to, step...)
+	case minutes.name:
+		t.SetMinuteHash(h.from, h.to, step...)
+	case hours.name:
+		t.SetHourHash(h.from, h.to, step...)
+	case dates.name:
+		t.SetDateHash(h.from, h.to, step...)
+	case months.name:
+		t.SetMonthHash(schedule.Month(h.from), schedule.Month(h.to), step...)
+	case days.name:
+		t.SetDayHash(schedule.Weekday(h.from), schedule.Weekday(h.to), step...)
+	}
+}
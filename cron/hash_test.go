@@ -0,0 +1,186 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+func Test_parseHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		tok     token
+		b       bounds
+		want    hashItem
+		wantErr *ParseError
+	}{
+		{
+			name: "whole field",
+			tok:  token{text: "H", col: 3},
+			b:    minutes,
+			want: hashItem{b: minutes, from: 0, to: 59, col: 3},
+		}, {
+			name: "range",
+			tok:  token{text: "H(0-5)", col: 1},
+			b:    hours,
+			want: hashItem{b: hours, from: 0, to: 5, col: 1},
+		}, {
+			name: "step",
+			tok:  token{text: "H/15", col: 1},
+			b:    minutes,
+			want: hashItem{b: minutes, from: 0, to: 59, step: 15, col: 1},
+		}, {
+			name: "range and step",
+			tok:  token{text: "H(1-28)/7", col: 1},
+			b:    dates,
+			want: hashItem{b: dates, from: 1, to: 28, step: 7, col: 1},
+		}, {
+			name: "day names",
+			tok:  token{text: "H(mon-fri)", col: 1},
+			b:    days,
+			want: hashItem{b: bounds{name: days.name, min: 0, max: 6, names: days.names}, from: 1, to: 5, col: 1},
+		}, {
+			name:    "sunday as 7",
+			tok:     token{text: "H(1-7)", col: 1},
+			b:       days,
+			wantErr: &ParseError{Field: days.name, Column: 5, Msg: "value 7 must be between 0 and 6"},
+		}, {
+			name:    "in a list",
+			tok:     token{text: "H,30", col: 1},
+			b:       minutes,
+			wantErr: &ParseError{Field: minutes.name, Column: 1, Msg: "H must be the only item of the field"},
+		}, {
+			name:    "unclosed range",
+			tok:     token{text: "H(0-5", col: 1},
+			b:       hours,
+			wantErr: &ParseError{Field: hours.name, Column: 2, Msg: "missing ) after H range"},
+		}, {
+			name:    "invalid step",
+			tok:     token{text: "H/x", col: 1},
+			b:       minutes,
+			wantErr: &ParseError{Field: minutes.name, Column: 3, Msg: `step "x" must be a positive number`},
+		}, {
+			name:    "trailing text",
+			tok:     token{text: "H5", col: 1},
+			b:       minutes,
+			wantErr: &ParseError{Field: minutes.name, Column: 2, Msg: `unexpected "5" after H`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHash(tt.tok, tt.b)
+			if tt.wantErr != nil {
+				if err == nil || !reflect.DeepEqual(err, tt.wantErr) {
+					t.Errorf("parseHash() error = %#v, wantErr %#v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("parseHash() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHash() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWithKey(t *testing.T) {
+	clk := schedule.NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		expr    string
+		key     string
+		want    func(t schedule.Timer) schedule.Timer
+		wantErr bool
+	}{
+		{
+			name: "hourly",
+			expr: "H * * * *",
+			key:  "backup",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMinuteHash(0, 59).SetHourRange(0, 23).SetMonthRange(schedule.January, schedule.December).
+					SetDateRange(1, 31).SetDayRange(schedule.Sunday, schedule.Saturday).SetHashKey("backup")
+			},
+		}, {
+			name: "nightly on business days",
+			expr: "H H(0-5) * * H(1-5)/2",
+			key:  "report",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMonthRange(schedule.January, schedule.December).
+					SetDateRange(1, 31).SetMinuteHash(0, 59).SetHourHash(0, 5).SetDayHash(schedule.Monday, schedule.Friday, 2).
+					SetHashKey("report")
+			},
+		}, {
+			name: "without hash",
+			expr: "0 9 * * *",
+			key:  "report",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMinutes(0).SetHours(9).SetMonthRange(schedule.January, schedule.December).
+					SetDateRange(1, 31).SetDayRange(schedule.Sunday, schedule.Saturday).SetHashKey("report")
+			},
+		}, {
+			name:    "no key",
+			expr:    "H * * * *",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWithKey(tt.expr, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWithKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			tr, ok := got.(*schedule.Timer)
+			if !ok {
+				t.Errorf("ParseWithKey() = %T, want *schedule.Timer", got)
+				return
+			}
+			if want := tt.want(schedule.ByTimestamp(true).SetClock(clk)); !reflect.DeepEqual(tr.SetClock(clk), want) {
+				t.Errorf("ParseWithKey() = %+v, want %+v", tr, want)
+			}
+		})
+	}
+}
+
+func TestParseWithKey_stable(t *testing.T) {
+	after := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	next := func(key string) time.Time {
+		s, err := ParseWithKey("H H * * *", key)
+		if err != nil {
+			t.Fatalf("ParseWithKey() error = %v", err)
+		}
+		at, err := s.(schedule.Previewer).NextAfter(after)
+		if err != nil {
+			t.Fatalf("Previewer.NextAfter() error = %v", err)
+		}
+		return at
+	}
+	if a, b := next("backup"), next("backup"); !a.Equal(b) {
+		t.Errorf("ParseWithKey() runs at %v and %v, want the same time for the same key", a, b)
+	}
+	if a, b := next("backup"), next("report"); a.Equal(b) {
+		t.Errorf("ParseWithKey() runs at %v for both keys, want different times", a)
+	}
+}
+
+func TestParse_hash(t *testing.T) {
+	_, err := Parse("0 H * * *")
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Parse() error = %v, want *ParseError", err)
+	}
+	want := &ParseError{Spec: "0 H * * *", Field: "hour", Column: 3, Msg: "H needs a key to be derived from, see ParseWithKey"}
+	if !reflect.DeepEqual(perr, want) {
+		t.Errorf("Parse() error = %#v, want %#v", perr, want)
+	}
+}
@@ -0,0 +1,556 @@
+package cron
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+// ParseNatural parses a schedule written in plain English, such as the ones typed to a chat bot.
+// Intervals are returned as *schedule.Interval and times of the day as *schedule.Timer:
+//	every 2 hours                     every 2 hours from the time it is scheduled
+//	every 1h30m                       every hour and a half, in time.ParseDuration syntax
+//	in 45 minutes                     once, 45 minutes after the time it is scheduled
+//	every day at 9am and 5pm          every day at 09:00 and 17:00
+//	every weekday at 09:30            Monday to Friday at 09:30
+//	every Monday and Friday at noon   on Mondays and Fridays at 12:00
+//	first Monday of each month at 6pm on the first Monday of the month at 18:00
+//	the 15th of each month at 8am     on the 15th of the month at 08:00
+//	the last day of each month at midnight
+// A Timer runs in UTC unless the phrase ends with the name of a location, eg: "every day at 9am Europe/Berlin".
+// The phrase is read with a fixed grammar. Ambiguous phrases are rejected with an error saying how to write
+// them, eg: "at 9" could be morning or evening, and "every week" does not say which day.
+//
+// eg:
+//	...
+//	s, err := cron.ParseNatural("first Monday of each month at noon Europe/Berlin")
+//	...
+func ParseNatural(text string) (schedule.Scheduler, error) {
+	p := newPhrase(text)
+	if len(p.toks) == 0 {
+		return nil, p.fail(p.tok(), "empty schedule")
+	}
+	loc, locTok, err := p.location()
+	if err != nil {
+		return nil, err
+	}
+
+	var s schedule.Scheduler
+	switch {
+	case p.accept("in"):
+		s, err = p.interval(false)
+	case p.isInterval():
+		p.pos++
+		s, err = p.interval(true)
+	default:
+		s, err = p.timer(loc)
+		loc = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		return nil, p.fail(locTok, "a location only applies to times of the day, not to intervals")
+	}
+	if p.pos < len(p.toks) {
+		return nil, p.fail(p.tok(), "unexpected %q", p.tok().text)
+	}
+	return s, nil
+}
+
+// phrase is a natural language schedule, read one word at a time.
+type phrase struct {
+	text string
+	toks []token
+	pos  int // index of the current word.
+}
+
+// newPhrase splits the text into words. A comma ending a word separates the items of a list as "and" does.
+func newPhrase(text string) *phrase {
+	p := &phrase{text: text}
+	for _, tok := range split(text) {
+		if strings.HasSuffix(tok.text, ",") && len(tok.text) > 1 {
+			p.toks = append(p.toks, token{text: tok.text[:len(tok.text)-1], col: tok.col}, token{text: "and", col: tok.col + len(tok.text) - 1})
+			continue
+		}
+		p.toks = append(p.toks, tok)
+	}
+	return p
+}
+
+// tok returns the current word, or an empty token past the end of the text when every word is read.
+func (p *phrase) tok() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return token{col: len(p.text) + 1}
+}
+
+// word returns the current word in lower case, empty when every word is read.
+func (p *phrase) word() string {
+	return strings.ToLower(p.tok().text)
+}
+
+// peek returns the word after the current one in lower case, empty when there is none.
+func (p *phrase) peek() string {
+	if p.pos+1 < len(p.toks) {
+		return strings.ToLower(p.toks[p.pos+1].text)
+	}
+	return ""
+}
+
+// accept moves past the current word when it is one of the given words.
+func (p *phrase) accept(words ...string) bool {
+	w := p.word()
+	for _, v := range words {
+		if w == v {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+// expect moves past the current word, which must be one of the given words.
+func (p *phrase) expect(words ...string) *ParseError {
+	if p.accept(words...) {
+		return nil
+	}
+	return p.fail(p.tok(), "expected %q, found %s", strings.Join(words, `" or "`), p.found())
+}
+
+// found names the current word for error messages.
+func (p *phrase) found() string {
+	if p.pos >= len(p.toks) {
+		return "the end of the schedule"
+	}
+	return strconv.Quote(p.tok().text)
+}
+
+// fail returns an error pointing at the given word.
+func (p *phrase) fail(tok token, format string, args ...interface{}) *ParseError {
+	return &ParseError{Spec: p.text, Column: tok.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// location removes the location ending the phrase, if any, and returns it.
+func (p *phrase) location() (*time.Location, token, *ParseError) {
+	last := p.toks[len(p.toks)-1]
+	switch {
+	case strings.EqualFold(last.text, "UTC") || strings.EqualFold(last.text, "GMT"):
+		p.toks = p.toks[:len(p.toks)-1]
+		return time.UTC, last, nil
+	case strings.Contains(last.text, "/"):
+		loc, err := time.LoadLocation(last.text)
+		if err != nil {
+			return nil, last, p.fail(last, "unknown location %q", last.text)
+		}
+		p.toks = p.toks[:len(p.toks)-1]
+		return loc, last, nil
+	}
+	return nil, last, nil
+}
+
+// isInterval reports whether the phrase is an interval, written as "every" followed by a count or by a time unit
+// shorter than a day. "every day" is a time of the day, which must be given.
+func (p *phrase) isInterval() bool {
+	if p.word() != "every" {
+		return false
+	}
+	next := p.peek()
+	if _, ok := count(next); ok {
+		return true
+	}
+	if _, err := time.ParseDuration(next); err == nil {
+		return true
+	}
+	u, ok := intervalUnits[next]
+	return ok && u < unitDay
+}
+
+// units of an interval, from the shortest to the longest.
+const (
+	unitSecond = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+)
+
+// intervalUnits maps the names of the time units to the units of an Interval.
+var intervalUnits = map[string]int{
+	"second": unitSecond, "seconds": unitSecond, "sec": unitSecond, "secs": unitSecond,
+	"minute": unitMinute, "minutes": unitMinute, "min": unitMinute, "mins": unitMinute,
+	"hour": unitHour, "hours": unitHour, "hr": unitHour, "hrs": unitHour,
+	"day": unitDay, "days": unitDay,
+	"week": unitWeek, "weeks": unitWeek,
+	"month": unitMonth, "months": unitMonth,
+	"year": unitYear, "years": unitYear,
+}
+
+// unitLengths are the lengths of the units of an interval, the months and years being taken at their longest.
+var unitLengths = [unitYear + 1]time.Duration{
+	time.Second, time.Minute, time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, 31 * 24 * time.Hour, 366 * 24 * time.Hour,
+}
+
+// numbers maps the numbers written as words to their value.
+var numbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// count parses a count of time units, written with digits or as a word.
+func count(word string) (int, bool) {
+	if n, ok := numbers[word]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(word)
+	return n, err == nil
+}
+
+// interval parses the counts of time units of an interval, eg: "2 hours 30 minutes", "an hour" or "1h30m".
+// A time unit without a count is counted once, eg: "every hour".
+func (p *phrase) interval(repeat bool) (*schedule.Interval, *ParseError) {
+	var counts [unitYear + 1]int
+	var d, total time.Duration // total is the longest length of the interval, which must fit in a time.Duration.
+	for first := true; p.pos < len(p.toks); first = false {
+		if !first && !p.accept("and") && !isCount(p.word()) {
+			break
+		}
+		tok, w := p.tok(), p.word()
+		n, isNum := count(w)
+		if dur, err := time.ParseDuration(w); err == nil && !isNum {
+			if dur <= 0 {
+				return nil, p.fail(tok, "duration %s must be positive", dur)
+			}
+			if dur > math.MaxInt64-total {
+				return nil, p.fail(tok, "duration %s makes the interval too long, it must be shorter than 292 years", dur)
+			}
+			d, total = d+dur, total+dur
+			p.pos++
+			continue
+		}
+
+		switch {
+		case isNum && n <= 0:
+			return nil, p.fail(tok, "count %d must be positive", n)
+		case isNum:
+			p.pos++
+		case first:
+			n = 1
+		default:
+			return nil, p.fail(tok, "expected a count of time units, found %s", p.found())
+		}
+		u, ok := intervalUnits[p.word()]
+		if !ok {
+			return nil, p.fail(p.tok(), "expected a time unit such as minutes or hours, found %s", p.found())
+		}
+		if time.Duration(n) > (math.MaxInt64-total)/unitLengths[u] {
+			return nil, p.fail(tok, "count %d makes the interval too long, it must be shorter than 292 years", n)
+		}
+		counts[u] += n
+		total += time.Duration(n) * unitLengths[u]
+		p.pos++
+	}
+	if p.word() == "at" {
+		return nil, p.fail(p.tok(), `an interval runs from the time it is scheduled and cannot be given a time of the day, eg: write "every day at 9am"`)
+	}
+
+	i := schedule.ByFreq(repeat)
+	i.AddYear(counts[unitYear]).
+		AddMonth(counts[unitMonth]).
+		AddWeek(counts[unitWeek]).
+		AddDay(counts[unitDay]).
+		AddHour(counts[unitHour] + int(d/time.Hour)).
+		AddMinute(counts[unitMinute] + int(d%time.Hour/time.Minute)).
+		AddSecond(counts[unitSecond] + int(d%time.Minute/time.Second)).
+		AddNsec(int(d % time.Second))
+	return i, nil
+}
+
+// isCount reports whether the word starts another count of time units of an interval.
+func isCount(word string) bool {
+	if _, ok := count(word); ok {
+		return true
+	}
+	_, err := time.ParseDuration(word)
+	return err == nil
+}
+
+// timer parses the days and the times of the day of a clock time based schedule, eg: "every weekday at 9am".
+func (p *phrase) timer(loc *time.Location) (*schedule.Timer, *ParseError) {
+	t := schedule.ByTimestamp(true)
+	if err := p.days(t); err != nil {
+		return nil, err
+	}
+	if p.word() != "at" {
+		return nil, p.fail(p.tok(), `missing the time of the day, eg: "at 9am", found %s`, p.found())
+	}
+	p.pos++
+	if err := p.times(t); err != nil {
+		return nil, err
+	}
+	if loc != nil {
+		t.SetLocation(loc)
+	}
+	return t, nil
+}
+
+// days parses the days of a clock time based schedule:
+//	every day, daily
+//	every weekday, every weekend
+//	every Monday and Friday
+//	the first Monday of each month, the last Friday of every month
+//	the 1st and 15th of each month, the last day of each month
+func (p *phrase) days(t *schedule.Timer) *ParseError {
+	p.accept("on")
+	if p.accept("daily") {
+		return nil
+	}
+	if !p.accept("every", "each") {
+		p.accept("the")
+		return p.monthDays(t)
+	}
+
+	tok, w := p.tok(), p.word()
+	switch w {
+	case "day":
+		p.pos++
+		return nil
+	case "weekday":
+		p.pos++
+		t.SetDayRange(schedule.Monday, schedule.Friday)
+		return nil
+	case "weekend":
+		p.pos++
+		t.SetDays(schedule.Sunday, schedule.Saturday)
+		return nil
+	case "week", "month", "year":
+		return p.fail(tok, `%q does not say which day, eg: write "every Monday at 9am", or "every 1 %s" for an interval`, "every "+w, w)
+	}
+	if _, ok := weekdayOf(w); ok {
+		wds, err := p.weekdays()
+		if err != nil {
+			return err
+		}
+		t.SetDays(wds...)
+		return nil
+	}
+	if _, ok := nths[w]; ok {
+		return p.monthDays(t)
+	}
+	return p.fail(tok, "expected a day, a weekday or a count of time units after \"every\", found %s", p.found())
+}
+
+// weekdays parses a list of weekdays, eg: "Monday, Wednesday and Friday".
+func (p *phrase) weekdays() ([]schedule.Weekday, *ParseError) {
+	var wds []schedule.Weekday
+	for {
+		wd, ok := weekdayOf(p.word())
+		if !ok {
+			return nil, p.fail(p.tok(), "expected a weekday, found %s", p.found())
+		}
+		wds = append(wds, wd)
+		p.pos++
+		if _, ok := weekdayOf(p.peek()); p.word() != "and" || !ok {
+			return wds, nil
+		}
+		p.pos++
+	}
+}
+
+// nths maps the occurrences of a weekday within the month to their number, -1 standing for the last one.
+var nths = map[string]int{"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1}
+
+// monthDays parses the days of the month, followed by "of each month":
+//	first Monday, last Friday
+//	1st and 15th, last day
+func (p *phrase) monthDays(t *schedule.Timer) *ParseError {
+	var dts []int
+	for first := true; first || p.accept("and"); first = false {
+		p.accept("the")
+		tok, w := p.tok(), p.word()
+		if n, ok := nths[w]; ok {
+			p.pos++
+			if p.accept("day") {
+				if n != -1 {
+					return p.fail(tok, `%q is always the %s, write "the %s"`, w+" day", ordinal(n), ordinal(n))
+				}
+				t.SetLastDate()
+				continue
+			}
+			wd, ok := weekdayOf(p.word())
+			if !ok {
+				return p.fail(p.tok(), "expected a weekday or \"day\" after %q, found %s", w, p.found())
+			}
+			p.pos++
+			if n == -1 {
+				t.SetLastDay(wd)
+			} else {
+				t.SetNthDay(wd, n)
+			}
+			continue
+		}
+		dt, ok := dateOf(w)
+		if !ok {
+			return p.fail(tok, `expected a day such as "every Monday", "the first Monday" or "the 15th", found %s`, p.found())
+		}
+		dts = append(dts, dt)
+		p.pos++
+	}
+	if dts != nil {
+		t.SetDates(dts...)
+	}
+
+	if err := p.expect("of"); err != nil {
+		return err
+	}
+	if err := p.expect("each", "every", "the"); err != nil {
+		return err
+	}
+	return p.expect("month")
+}
+
+// times parses the list of times of the day and sets them on the Timer.
+// The times must share either their hour or their minute, as the Timer runs at every combination of them.
+func (p *phrase) times(t *schedule.Timer) *ParseError {
+	type clock struct {
+		h, m int
+		tok  token
+	}
+	var clocks []clock
+	for first := true; first || p.accept("and"); first = false {
+		tok := p.tok()
+		h, m, err := p.clock()
+		if err != nil {
+			return err
+		}
+		clocks = append(clocks, clock{h: h, m: m, tok: tok})
+	}
+
+	var hrs, mins []int
+	sameHour, sameMinute := true, true
+	for _, c := range clocks {
+		hrs, mins = append(hrs, c.h), append(mins, c.m)
+		sameHour = sameHour && c.h == clocks[0].h
+		sameMinute = sameMinute && c.m == clocks[0].m
+	}
+	switch {
+	case sameMinute:
+		mins = mins[:1]
+	case sameHour:
+		hrs = hrs[:1]
+	default:
+		return p.fail(clocks[1].tok, "times %02d:%02d and %02d:%02d differ in both hour and minute, schedule them separately",
+			clocks[0].h, clocks[0].m, clocks[1].h, clocks[1].m)
+	}
+	t.SetHours(hrs...).SetMinutes(mins...).SetSeconds(0)
+	return nil
+}
+
+// clock parses a time of the day: 9am, 9:30 pm, 17:45, noon or midnight.
+// A number alone is ambiguous, as it may be in the morning or in the evening.
+func (p *phrase) clock() (h, m int, err *ParseError) {
+	tok, w := p.tok(), p.word()
+	switch w {
+	case "noon", "midday":
+		p.pos++
+		return 12, 0, nil
+	case "midnight":
+		p.pos++
+		return 0, 0, nil
+	case "":
+		return 0, 0, p.fail(tok, "expected a time of the day, found %s", p.found())
+	}
+	p.pos++
+
+	meridiem := ""
+	switch {
+	case strings.HasSuffix(w, "am") || strings.HasSuffix(w, "pm"):
+		w, meridiem = w[:len(w)-2], w[len(w)-2:]
+	case p.word() == "am" || p.word() == "pm":
+		meridiem = p.word()
+		p.pos++
+	}
+
+	hh, mm, hasMinutes := strings.Cut(w, ":")
+	h, convErr := strconv.Atoi(hh)
+	if convErr != nil {
+		return 0, 0, p.fail(tok, "invalid time %q", tok.text)
+	}
+	if hasMinutes {
+		if m, convErr = strconv.Atoi(mm); convErr != nil || len(mm) != 2 || m > 59 {
+			return 0, 0, p.fail(tok, "invalid time %q", tok.text)
+		}
+	}
+	switch {
+	case meridiem != "":
+		if h < 1 || h > 12 {
+			return 0, 0, p.fail(tok, "hour %d must be between 1 and 12 with am or pm", h)
+		}
+		h %= 12
+		if meridiem == "pm" {
+			h += 12
+		}
+	case h < 0 || h > 23:
+		return 0, 0, p.fail(tok, "hour %d must be between 0 and 23", h)
+	case !hasMinutes && h >= 1 && h <= 12:
+		return 0, 0, p.fail(tok, "ambiguous time %q, write %dam, %dpm or %02d:00", tok.text, h, h, h)
+	case !hasMinutes:
+		return 0, 0, p.fail(tok, "ambiguous time %q, write %02d:00", tok.text, h)
+	}
+	return h, m, nil
+}
+
+// weekdayNames are the names of the weekdays, indexed by schedule.Weekday.
+var weekdayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// weekdayOf parses the name of a weekday, in full or shortened to at least 3 letters, in the singular or plural.
+func weekdayOf(word string) (schedule.Weekday, bool) {
+	if len(word) > 3 {
+		word = strings.TrimSuffix(word, "s")
+	}
+	if len(word) < 3 {
+		return 0, false
+	}
+	for i, name := range weekdayNames {
+		if strings.HasPrefix(name, word) {
+			return schedule.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// dateOf parses a date of the month written as an ordinal, eg: 1st, 22nd or 31st.
+func dateOf(word string) (int, bool) {
+	if len(word) < 3 {
+		return 0, false
+	}
+	dt, err := strconv.Atoi(word[:len(word)-2])
+	if err != nil || dt < dates.min || dt > dates.max || ordinal(dt) != word {
+		return 0, false
+	}
+	return dt, true
+}
+
+// ordinal returns the ordinal of the number, eg: 1st, 2nd, 11th or 23rd.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,204 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+func TestParseNatural(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		timer bool
+		want  string
+	}{
+		{
+			name: "interval",
+			text: "every 2 hours",
+			want: "every 2 hours",
+		}, {
+			name: "interval of several units",
+			text: "Every 2 hours and 30 minutes",
+			want: "every 2 hours 30 minutes",
+		}, {
+			name: "interval as a duration",
+			text: "every 1h30m",
+			want: "every 1 hour 30 minutes",
+		}, {
+			name: "interval without a count",
+			text: "every minute",
+			want: "every minute",
+		}, {
+			name: "interval in words",
+			text: "every two weeks",
+			want: "every 2 weeks",
+		}, {
+			name: "once",
+			text: "in 45 minutes",
+			want: "once after 45 minutes",
+		}, {
+			name: "once in words",
+			text: "in an hour",
+			want: "once after 1 hour",
+		}, {
+			name:  "weekdays",
+			text:  "every weekday at 9am",
+			timer: true,
+			want:  "every weekday at 09:00 UTC",
+		}, {
+			name:  "first weekday of the month in a location",
+			text:  "first Monday of each month at noon Europe/Berlin",
+			timer: true,
+			want:  "the first Monday of every month at 12:00 Europe/Berlin",
+		}, {
+			name:  "list of weekdays",
+			text:  "every Monday, Wednesday and Friday at 6:30pm",
+			timer: true,
+			want:  "every Monday, Wednesday and Friday at 18:30 UTC",
+		}, {
+			name:  "daily at two times",
+			text:  "daily at 9am and 5 pm",
+			timer: true,
+			want:  "every day at 09:00 and 17:00 UTC",
+		}, {
+			name:  "times of the same hour",
+			text:  "every day at 9:00 and 9:30",
+			timer: true,
+			want:  "every day at 09:00 and 09:30 UTC",
+		}, {
+			name:  "dates of the month",
+			text:  "the 1st and 15th of each month at 8am",
+			timer: true,
+			want:  "the 1st and 15th of every month at 08:00 UTC",
+		}, {
+			name:  "last day of the month",
+			text:  "on the last day of every month at midnight",
+			timer: true,
+			want:  "the last day of every month at 00:00 UTC",
+		}, {
+			name:  "last weekday of the month",
+			text:  "the last Friday of the month at 17:00 UTC",
+			timer: true,
+			want:  "the last Friday of every month at 17:00 UTC",
+		}, {
+			name:  "weekends",
+			text:  "every weekend at 12am",
+			timer: true,
+			want:  "every Sunday and Saturday at 00:00 UTC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNatural(tt.text)
+			if err != nil {
+				t.Errorf("ParseNatural() error = %v", err)
+				return
+			}
+			var desc string
+			switch s := got.(type) {
+			case *schedule.Timer:
+				if !tt.timer {
+					t.Errorf("ParseNatural() = %T, want *schedule.Interval", got)
+				}
+				desc = s.Describe()
+			case *schedule.Interval:
+				if tt.timer {
+					t.Errorf("ParseNatural() = %T, want *schedule.Timer", got)
+				}
+				desc = s.Describe()
+			}
+			if desc != tt.want {
+				t.Errorf("ParseNatural() = %q, want %q", desc, tt.want)
+			}
+			if _, err := got.Next(); err != nil {
+				t.Errorf("Next() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseNatural_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr *ParseError
+	}{
+		{
+			name:    "empty",
+			text:    " ",
+			wantErr: &ParseError{Spec: " ", Column: 2, Msg: "empty schedule"},
+		}, {
+			name:    "ambiguous time",
+			text:    "every day at 9",
+			wantErr: &ParseError{Spec: "every day at 9", Column: 14, Msg: `ambiguous time "9", write 9am, 9pm or 09:00`},
+		}, {
+			name:    "no day",
+			text:    "every week at 9am",
+			wantErr: &ParseError{Spec: "every week at 9am", Column: 7, Msg: `"every week" does not say which day, eg: write "every Monday at 9am", or "every 1 week" for an interval`},
+		}, {
+			name:    "no time",
+			text:    "every monday",
+			wantErr: &ParseError{Spec: "every monday", Column: 13, Msg: `missing the time of the day, eg: "at 9am", found the end of the schedule`},
+		}, {
+			name:    "interval at a time",
+			text:    "every 2 hours at 9am",
+			wantErr: &ParseError{Spec: "every 2 hours at 9am", Column: 15, Msg: `an interval runs from the time it is scheduled and cannot be given a time of the day, eg: write "every day at 9am"`},
+		}, {
+			name:    "interval in a location",
+			text:    "in 10 minutes Europe/Berlin",
+			wantErr: &ParseError{Spec: "in 10 minutes Europe/Berlin", Column: 15, Msg: "a location only applies to times of the day, not to intervals"},
+		}, {
+			name:    "unknown location",
+			text:    "every day at 9am Mars/Olympus",
+			wantErr: &ParseError{Spec: "every day at 9am Mars/Olympus", Column: 18, Msg: `unknown location "Mars/Olympus"`},
+		}, {
+			name:    "times differing in hour and minute",
+			text:    "every day at 9am and 5:30pm",
+			wantErr: &ParseError{Spec: "every day at 9am and 5:30pm", Column: 22, Msg: "times 09:00 and 17:30 differ in both hour and minute, schedule them separately"},
+		}, {
+			name:    "zero count",
+			text:    "every 0 minutes",
+			wantErr: &ParseError{Spec: "every 0 minutes", Column: 7, Msg: "count 0 must be positive"},
+		}, {
+			name:    "count overflowing the interval",
+			text:    "every 99999999999 hours",
+			wantErr: &ParseError{Spec: "every 99999999999 hours", Column: 7, Msg: "count 99999999999 makes the interval too long, it must be shorter than 292 years"},
+		}, {
+			name:    "counts overflowing the interval together",
+			text:    "every 200 years and 100 years",
+			wantErr: &ParseError{Spec: "every 200 years and 100 years", Column: 21, Msg: "count 100 makes the interval too long, it must be shorter than 292 years"},
+		}, {
+			name:    "hour out of range",
+			text:    "every day at 13pm",
+			wantErr: &ParseError{Spec: "every day at 13pm", Column: 14, Msg: "hour 13 must be between 1 and 12 with am or pm"},
+		}, {
+			name:    "trailing words",
+			text:    "every day at 9am tomorrow",
+			wantErr: &ParseError{Spec: "every day at 9am tomorrow", Column: 18, Msg: `unexpected "tomorrow"`},
+		}, {
+			name:    "invalid date",
+			text:    "the 32nd of each month at 9am",
+			wantErr: &ParseError{Spec: "the 32nd of each month at 9am", Column: 5, Msg: `expected a day such as "every Monday", "the first Monday" or "the 15th", found "32nd"`},
+		}, {
+			name:    "first day",
+			text:    "first day of each month at 9am",
+			wantErr: &ParseError{Spec: "first day of each month at 9am", Column: 1, Msg: `"first day" is always the 1st, write "the 1st"`},
+		}, {
+			name:    "missing month",
+			text:    "the 15th at 9am",
+			wantErr: &ParseError{Spec: "the 15th at 9am", Column: 10, Msg: `expected "of", found "at"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNatural(tt.text)
+			var perr *ParseError
+			if !errors.As(err, &perr) || !reflect.DeepEqual(perr, tt.wantErr) {
+				t.Errorf("ParseNatural() error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
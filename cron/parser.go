@@ -0,0 +1,377 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+// ParseError describes an invalid cron expression.
+// It points at the field and the column (1 based) of the spec where the problem was found.
+type ParseError struct {
+	Spec   string // expression which failed to parse.
+	Field  string // name of the field which is invalid, eg: "minute".
+	Column int    // column of the spec where the invalid token starts.
+	Msg    string // description of the problem.
+}
+
+// Error returns the string representation of the parse error.
+func (e *ParseError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("cron: invalid expression %q at column %d: %s", e.Spec, e.Column, e.Msg)
+	}
+	return fmt.Sprintf("cron: invalid %s field in %q at column %d: %s", e.Field, e.Spec, e.Column, e.Msg)
+}
+
+// bounds describes the accepted values of a cron field.
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+// fields of a cron expression in the order they are written.
+var (
+	seconds = bounds{name: "second", min: 0, max: 59}
+	minutes = bounds{name: "minute", min: 0, max: 59}
+	hours   = bounds{name: "hour", min: 0, max: 23}
+	dates   = bounds{name: "day of month", min: 1, max: 31}
+	months  = bounds{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week accepts 7 as an alias of sunday.
+	days = bounds{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// token is a part of the spec along with the column it starts at.
+type token struct {
+	text string
+	col  int
+}
+
+// spec is a parsed cron expression. Each field is stored as a bit set of the values it matches.
+type spec struct {
+	expr    string
+	second  uint64
+	minute  uint64
+	hour    uint64
+	date    uint64
+	month   uint64
+	day     uint64
+	anyDate bool // day of month field starts with `*` or `?`.
+	anyDay  bool // day of week field starts with `*` or `?`.
+
+	lastDate     bool     // day of month field has `L`, the last day of the month.
+	nearestDates uint64   // dates written as `15W`, the weekday nearest to the 15th, `LW` is stored as 31.
+	lastDays     uint64   // weekdays written as `5L`, the last friday of the month.
+	nthDays      [][2]int // weekdays written as `5#2`, the second friday of the month.
+
+	hashes []hashItem // fields written as `H`, derived from the hash of a key.
+}
+
+// Parse parses a standard cron expression and returns the clock time based scheduler it describes,
+// as a *schedule.Timer running in UTC.
+//
+// The expression has 5 fields (minute, hour, day of month, month, day of week),
+// or 6 fields when a leading second field is given. Every field accepts
+//	5        a single value
+//	*        every value of the field
+//	1-5      a range of values, both ends included
+//	*/15     every 15th value of the field
+//	10-40/5  every 5th value within the range
+//	1,15,30  a list of any of the above
+// Month and day of week also accept names (JAN-DEC and SUN-SAT, case insensitive),
+// and day of week accepts both 0 and 7 for sunday. `?` is accepted in place of `*`
+// for day of month and day of week.
+// Day of month accepts `L` for the last day of the month, `15W` for the weekday nearest to
+// the 15th and `LW` for the last weekday of the month. Day of week accepts `5L` for
+// the last friday of the month and `5#2` for the second friday of the month.
+// When both day of month and day of week are restricted, the expression matches a day
+// when either of them matches, as with standard cron.
+// Predefined schedules such as @daily or @every 1h are accepted as well, see ParseDescriptor.
+// Fields written as H need a key to be derived from, see ParseWithKey.
+//
+// eg:
+//	...
+//	s, err := cron.Parse("*/15 9-17 * * MON-FRI")   // every 15 minutes during business hours.
+//	...
+func Parse(expr string) (schedule.Scheduler, error) {
+	return ParseWithKey(expr, "")
+}
+
+// parse parses the fields of a cron expression into bit sets.
+func parse(expr string) (*spec, error) {
+	toks := split(expr)
+	switch len(toks) {
+	case 5:
+		toks = append([]token{{text: "0"}}, toks...)
+	case 6:
+	default:
+		col := 1
+		if len(toks) > 6 {
+			col = toks[6].col
+		}
+		return nil, &ParseError{Spec: expr, Column: col, Msg: fmt.Sprintf("expected 5 or 6 fields, found %d", len(toks))}
+	}
+
+	s := &spec{expr: expr}
+	for i, f := range []struct {
+		dst *uint64
+		b   bounds
+	}{
+		{&s.second, seconds}, {&s.minute, minutes}, {&s.hour, hours},
+		{&s.date, dates}, {&s.month, months}, {&s.day, days},
+	} {
+		bits, err := parseField(toks[i], f.b, s)
+		if err != nil {
+			err.Spec = expr
+			return nil, err
+		}
+		*f.dst = bits
+	}
+
+	// sunday can be written as 0 or 7.
+	if s.day&(1<<7) != 0 {
+		s.day = s.day&^(1<<7) | 1
+	}
+	if s.lastDays&(1<<7) != 0 {
+		s.lastDays = s.lastDays&^(1<<7) | 1
+	}
+	for i := range s.nthDays {
+		s.nthDays[i][0] %= 7
+	}
+	s.anyDate = strings.HasPrefix(toks[3].text, "*") || strings.HasPrefix(toks[3].text, "?")
+	s.anyDay = strings.HasPrefix(toks[5].text, "*") || strings.HasPrefix(toks[5].text, "?")
+	return s, nil
+}
+
+// split splits the expression on white spaces, keeping track of the column of each field.
+func split(expr string) (toks []token) {
+	start := -1
+	for i, r := range expr + " " {
+		if r == ' ' || r == '\t' || r == '\n' {
+			if start >= 0 {
+				toks = append(toks, token{text: expr[start:i], col: start + 1})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	return toks
+}
+
+// parseField parses a comma separated list of values, ranges and steps into a bit set.
+// The last and nth day items of day of month and day of week fields, and the fields written as H,
+// are recorded on the spec.
+func parseField(tok token, b bounds, s *spec) (uint64, *ParseError) {
+	if strings.HasPrefix(strings.ToUpper(tok.text), "H") {
+		h, err := parseHash(tok, b)
+		if err != nil {
+			return 0, err
+		}
+		s.hashes = append(s.hashes, h)
+		return 0, nil
+	}
+
+	var bits uint64
+	col := tok.col
+	for _, item := range strings.Split(tok.text, ",") {
+		itemTok := token{text: item, col: col}
+		col += len(item) + 1
+
+		if ok, err := parseSpecial(itemTok, b, s); err != nil {
+			return 0, err
+		} else if ok {
+			continue
+		}
+		itemBits, err := parseItem(itemTok, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+// parseSpecial parses the items relative to the end of the month or to the weekday occurrences:
+//	L     the last day of the month, in day of month
+//	15W   the weekday nearest to the 15th, in day of month
+//	LW    the last weekday of the month, in day of month
+//	5L    the last friday of the month, in day of week
+//	5#2   the second friday of the month, in day of week
+// It reports false when the item is not one of them.
+func parseSpecial(tok token, b bounds, s *spec) (bool, *ParseError) {
+	text := strings.ToUpper(tok.text)
+	switch {
+	case b.name == dates.name && text == "L":
+		s.lastDate = true
+		return true, nil
+
+	case b.name == dates.name && text == "LW":
+		// the weekday nearest to the last date is the last weekday of the month.
+		s.nearestDates |= 1 << 31
+		return true, nil
+
+	case b.name == dates.name && len(text) > 1 && strings.HasSuffix(text, "W"):
+		v, err := parseValue(token{text: tok.text[:len(tok.text)-1], col: tok.col}, b)
+		if err != nil {
+			return false, err
+		}
+		s.nearestDates |= 1 << v
+		return true, nil
+
+	case b.name == days.name && len(text) > 1 && strings.HasSuffix(text, "L"):
+		v, err := parseValue(token{text: tok.text[:len(tok.text)-1], col: tok.col}, b)
+		if err != nil {
+			return false, err
+		}
+		s.lastDays |= 1 << v
+		return true, nil
+
+	case b.name == days.name && strings.Contains(text, "#"):
+		day, nth, _ := strings.Cut(tok.text, "#")
+		v, err := parseValue(token{text: day, col: tok.col}, b)
+		if err != nil {
+			return false, err
+		}
+		n, convErr := strconv.Atoi(nth)
+		if convErr != nil || n < 1 || n > 5 {
+			return false, &ParseError{Field: b.name, Column: tok.col + len(day) + 1, Msg: fmt.Sprintf("occurrence %q must be between 1 and 5", nth)}
+		}
+		s.nthDays = append(s.nthDays, [2]int{v, n})
+		return true, nil
+	}
+	return false, nil
+}
+
+// parseItem parses a single value, range or step of a field into a bit set.
+func parseItem(tok token, b bounds) (uint64, *ParseError) {
+	fail := func(col int, format string, args ...interface{}) (uint64, *ParseError) {
+		return 0, &ParseError{Field: b.name, Column: col, Msg: fmt.Sprintf(format, args...)}
+	}
+	if tok.text == "" {
+		return fail(tok.col, "empty value")
+	}
+
+	rng, step, hasStep := strings.Cut(tok.text, "/")
+	lo, hi := b.min, b.max
+	switch {
+	case rng == "*" || rng == "?":
+	default:
+		first, last, isRange := strings.Cut(rng, "-")
+		var err *ParseError
+		if lo, err = parseValue(token{text: first, col: tok.col}, b); err != nil {
+			return 0, err
+		}
+		hi = lo
+		if isRange {
+			if hi, err = parseValue(token{text: last, col: tok.col + len(first) + 1}, b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return fail(tok.col, "range start %d is beyond range end %d", lo, hi)
+			}
+		} else if hasStep {
+			// a/n is shorthand for a-max/n
+			hi = b.max
+		}
+	}
+
+	every := 1
+	if hasStep {
+		col := tok.col + len(rng) + 1
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fail(col, "step %q must be a positive number", step)
+		}
+		if n > b.max-b.min+1 {
+			return fail(col, "step %d is beyond the range of the field %d-%d", n, b.min, b.max)
+		}
+		every = n
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += every {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+// parseValue parses a number or a name of the field and checks it is within the bounds.
+func parseValue(tok token, b bounds) (int, *ParseError) {
+	if v, ok := b.names[strings.ToLower(tok.text)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return 0, &ParseError{Field: b.name, Column: tok.col, Msg: fmt.Sprintf("invalid value %q", tok.text)}
+	}
+	if v < b.min || v > b.max {
+		return 0, &ParseError{Field: b.name, Column: tok.col, Msg: fmt.Sprintf("value %d must be between %d and %d", v, b.min, b.max)}
+	}
+	return v, nil
+}
+
+// timer converts the parsed expression to a clock time based scheduler.
+func (s *spec) timer() *schedule.Timer {
+	t := schedule.ByTimestamp(true)
+	t.SetSeconds(values(s.second)...).
+		SetMinutes(values(s.minute)...).
+		SetHours(values(s.hour)...).
+		SetMonths(monthsOf(s.month)...).
+		SetDates(values(s.date)...).
+		SetDays(weekdaysOf(s.day)...)
+	if s.lastDate {
+		t.SetLastDate()
+	}
+	for _, v := range values(s.nearestDates) {
+		t.SetNearestWeekday(v)
+	}
+	for _, v := range weekdaysOf(s.lastDays) {
+		t.SetLastDay(v)
+	}
+	for _, v := range s.nthDays {
+		t.SetNthDay(schedule.Weekday(v[0]), v[1])
+	}
+
+	// a date matching either the day of month or the day of week is a match, unless one of them starts with `*`,
+	// in which case both must match.
+	if !s.anyDate && !s.anyDay {
+		t.SetDayOrDate(true)
+	}
+	return t
+}
+
+// values returns the values of the bit set in increasing order.
+func values(bits uint64) (vals []int) {
+	for v := 0; v < 64; v++ {
+		if bits&(1<<v) != 0 {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+// monthsOf returns the months of the bit set in increasing order.
+func monthsOf(bits uint64) (vals []schedule.Month) {
+	for _, v := range values(bits) {
+		vals = append(vals, schedule.Month(v))
+	}
+	return vals
+}
+
+// weekdaysOf returns the weekdays of the bit set in increasing order.
+func weekdaysOf(bits uint64) (vals []schedule.Weekday) {
+	for _, v := range values(bits) {
+		vals = append(vals, schedule.Weekday(v))
+	}
+	return vals
+}
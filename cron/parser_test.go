@@ -0,0 +1,363 @@
+package cron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dev-asterix/executioner/cron/schedule"
+)
+
+// bits returns a bit set of the given values.
+func bits(vals ...int) (b uint64) {
+	for _, v := range vals {
+		b |= 1 << v
+	}
+	return b
+}
+
+// span returns a bit set of the values from lo to hi by step.
+func span(lo, hi, step int) (b uint64) {
+	for v := lo; v <= hi; v += step {
+		b |= 1 << v
+	}
+	return b
+}
+
+func Test_parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    *spec
+		wantErr *ParseError
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			want: &spec{
+				expr: "* * * * *", second: bits(0), minute: span(0, 59, 1), hour: span(0, 23, 1),
+				date: span(1, 31, 1), month: span(1, 12, 1), day: span(0, 6, 1),
+				anyDate: true, anyDay: true,
+			},
+		}, {
+			name: "business hours",
+			expr: "*/15 9-17 * * MON-FRI",
+			want: &spec{
+				expr: "*/15 9-17 * * MON-FRI", second: bits(0), minute: bits(0, 15, 30, 45), hour: span(9, 17, 1),
+				date: span(1, 31, 1), month: span(1, 12, 1), day: span(1, 5, 1),
+				anyDate: true,
+			},
+		}, {
+			name: "with seconds",
+			expr: "30 0 12 1,15 jan,Jul ?",
+			want: &spec{
+				expr: "30 0 12 1,15 jan,Jul ?", second: bits(30), minute: bits(0), hour: bits(12),
+				date: bits(1, 15), month: bits(1, 7), day: span(0, 6, 1),
+				anyDay: true,
+			},
+		}, {
+			name: "ranged steps and sunday as 7",
+			expr: "0 10-40/10 1/6 */10 * 5-7",
+			want: &spec{
+				expr: "0 10-40/10 1/6 */10 * 5-7", second: bits(0), minute: bits(10, 20, 30, 40), hour: bits(1, 7, 13, 19),
+				date: bits(1, 11, 21, 31), month: span(1, 12, 1), day: bits(0, 5, 6),
+				anyDate: true,
+			},
+		}, {
+			name: "last day of month",
+			expr: "0 0 1,L * *",
+			want: &spec{
+				expr: "0 0 1,L * *", second: bits(0), minute: bits(0), hour: bits(0),
+				date: bits(1), month: span(1, 12, 1), day: span(0, 6, 1),
+				anyDay: true, lastDate: true,
+			},
+		}, {
+			name: "nearest and last weekday of month",
+			expr: "0 0 15W,LW * *",
+			want: &spec{
+				expr: "0 0 15W,LW * *", second: bits(0), minute: bits(0), hour: bits(0),
+				month: span(1, 12, 1), day: span(0, 6, 1),
+				anyDay: true, nearestDates: bits(15, 31),
+			},
+		}, {
+			name:    "invalid nearest weekday",
+			expr:    "0 0 32W * *",
+			wantErr: &ParseError{Spec: "0 0 32W * *", Field: "day of month", Column: 5, Msg: "value 32 must be between 1 and 31"},
+		}, {
+			name:    "nearest weekday in day of week",
+			expr:    "0 0 * * 5W",
+			wantErr: &ParseError{Spec: "0 0 * * 5W", Field: "day of week", Column: 9, Msg: `invalid value "5W"`},
+		}, {
+			name: "last and nth weekday",
+			expr: "0 0 ? * 5L,7L,tue#2,0#5",
+			want: &spec{
+				expr: "0 0 ? * 5L,7L,tue#2,0#5", second: bits(0), minute: bits(0), hour: bits(0),
+				date: span(1, 31, 1), month: span(1, 12, 1),
+				anyDate: true, lastDays: bits(0, 5), nthDays: [][2]int{{2, 2}, {0, 5}},
+			},
+		}, {
+			name:    "invalid last weekday",
+			expr:    "0 0 * * 8L",
+			wantErr: &ParseError{Spec: "0 0 * * 8L", Field: "day of week", Column: 9, Msg: "value 8 must be between 0 and 7"},
+		}, {
+			name:    "invalid occurrence",
+			expr:    "0 0 * * MON#6",
+			wantErr: &ParseError{Spec: "0 0 * * MON#6", Field: "day of week", Column: 13, Msg: `occurrence "6" must be between 1 and 5`},
+		}, {
+			name:    "last weekday in day of month",
+			expr:    "0 0 5L * *",
+			wantErr: &ParseError{Spec: "0 0 5L * *", Field: "day of month", Column: 5, Msg: `invalid value "5L"`},
+		}, {
+			name:    "too few fields",
+			expr:    "* * * *",
+			wantErr: &ParseError{Spec: "* * * *", Column: 1, Msg: "expected 5 or 6 fields, found 4"},
+		}, {
+			name:    "too many fields",
+			expr:    "* * * * * * 2020",
+			wantErr: &ParseError{Spec: "* * * * * * 2020", Column: 13, Msg: "expected 5 or 6 fields, found 7"},
+		}, {
+			name:    "minute out of range",
+			expr:    "0 60 * * * *",
+			wantErr: &ParseError{Spec: "0 60 * * * *", Field: "minute", Column: 3, Msg: "value 60 must be between 0 and 59"},
+		}, {
+			name:    "invalid list item",
+			expr:    "0  5 1,2,x * * *",
+			wantErr: &ParseError{Spec: "0  5 1,2,x * * *", Field: "hour", Column: 10, Msg: `invalid value "x"`},
+		}, {
+			name:    "empty list item",
+			expr:    "* * 1,,2 * *",
+			wantErr: &ParseError{Spec: "* * 1,,2 * *", Field: "day of month", Column: 7, Msg: "empty value"},
+		}, {
+			name:    "invalid range end",
+			expr:    "* * * JAN-FOO *",
+			wantErr: &ParseError{Spec: "* * * JAN-FOO *", Field: "month", Column: 11, Msg: `invalid value "FOO"`},
+		}, {
+			name:    "reversed range",
+			expr:    "* * * * FRI-MON",
+			wantErr: &ParseError{Spec: "* * * * FRI-MON", Field: "day of week", Column: 9, Msg: "range start 5 is beyond range end 1"},
+		}, {
+			name:    "zero step",
+			expr:    "*/0 * * * *",
+			wantErr: &ParseError{Spec: "*/0 * * * *", Field: "minute", Column: 3, Msg: `step "0" must be a positive number`},
+		}, {
+			name:    "step beyond field",
+			expr:    "* */25 * * *",
+			wantErr: &ParseError{Spec: "* */25 * * *", Field: "hour", Column: 5, Msg: "step 25 is beyond the range of the field 0-23"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.expr)
+			if tt.wantErr != nil {
+				var perr *ParseError
+				if !errors.As(err, &perr) || !reflect.DeepEqual(perr, tt.wantErr) {
+					t.Errorf("parse() error = %#v, wantErr %#v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("parse() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	clk := schedule.NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name    string
+		expr    string
+		want    func(t schedule.Timer) schedule.Timer
+		wantErr bool
+	}{
+		{
+			name: "business hours",
+			expr: "*/15 9-17 * * MON-FRI",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMinutes(0, 15, 30, 45).SetHourRange(9, 17).SetMonthRange(schedule.January, schedule.December).
+					SetDateRange(1, 31).SetDayRange(schedule.Monday, schedule.Friday)
+			},
+		}, {
+			name: "day of month or day of week",
+			expr: "30 0 12 13 * FRI",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(30).SetMinutes(0).SetHours(12).SetMonthRange(schedule.January, schedule.December).
+					SetDates(13).SetDays(schedule.Friday).SetDayOrDate(true)
+			},
+		}, {
+			name: "month end and second tuesday",
+			expr: "0 0 L * TUE#2",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMinutes(0).SetHours(0).SetMonthRange(schedule.January, schedule.December).
+					SetLastDate().SetNthDay(schedule.Tuesday, 2).SetDayOrDate(true)
+			},
+		}, {
+			name: "nearest weekday",
+			expr: "0 30 9 15W * *",
+			want: func(t schedule.Timer) schedule.Timer {
+				return t.SetSeconds(0).SetMinutes(30).SetHours(9).SetMonthRange(schedule.January, schedule.December).
+					SetDayRange(schedule.Sunday, schedule.Saturday).SetNearestWeekday(15)
+			},
+		}, {
+			name:    "invalid",
+			expr:    "* * * *",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			tr, ok := got.(*schedule.Timer)
+			if !ok {
+				t.Errorf("Parse() = %T, want *schedule.Timer", got)
+				return
+			}
+			if want := tt.want(schedule.ByTimestamp(true).SetClock(clk)); !reflect.DeepEqual(tr.SetClock(clk), want) {
+				t.Errorf("Parse() = %+v, want %+v", tr, want)
+			}
+		})
+	}
+}
+
+func TestParse_NextAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		after   time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name:  "next quarter hour",
+			expr:  "*/15 9-17 * * MON-FRI",
+			after: time.Date(2020, time.January, 1, 9, 7, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 1, 9, 15, 0, 0, time.UTC),
+		}, {
+			name:  "skips the weekend",
+			expr:  "*/15 9-17 * * MON-FRI",
+			after: time.Date(2020, time.January, 3, 17, 45, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 6, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:  "strictly after",
+			expr:  "30 * * * * *",
+			after: time.Date(2020, time.January, 1, 0, 0, 30, 0, time.UTC),
+			want:  time.Date(2020, time.January, 1, 0, 1, 30, 0, time.UTC),
+		}, {
+			name:  "leap day",
+			expr:  "0 0 29 2 *",
+			after: time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "day of month or day of week",
+			expr:  "0 0 13 * FRI",
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "every other day of month",
+			expr:  "0 0 */2 * *",
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "every other day of month across months",
+			expr:  "0 0 */2 * *",
+			after: time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "every other day of week",
+			expr:  "0 0 * * */2",
+			after: time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), // Thursday
+			want:  time.Date(2020, time.January, 4, 0, 0, 0, 0, time.UTC), // Saturday
+		}, {
+			name:  "every other day of week across weeks",
+			expr:  "0 0 * * */2",
+			after: time.Date(2020, time.January, 4, 0, 0, 0, 0, time.UTC), // Saturday
+			want:  time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC), // Sunday
+		}, {
+			name:  "every other day of month on a weekday",
+			expr:  "0 0 */2 * MON",
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 13, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:    "never matches",
+			expr:    "0 0 30 2 *",
+			after:   time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: schedule.ErrNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := s.(*schedule.Timer).NextAfter(tt.after)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Timer.NextAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Timer.NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Next(t *testing.T) {
+	clk := schedule.NewFakeClock(time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC))
+	s, err := Parse("0 0 * * *")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	next, err := s.(*schedule.Timer).SetClock(clk).Next()
+	if err != nil {
+		t.Fatalf("Timer.Next() error = %v", err)
+	}
+	want := time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)
+	if got, _ := next.(*schedule.Timer).NextAfter(clk.Now()); !got.Equal(want) {
+		t.Errorf("Timer.Next() armed for %v, want %v", got, want)
+	}
+
+	if s, err = Parse("0 0 30 2 *"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if _, err := s.(*schedule.Timer).SetClock(clk).Next(); !errors.Is(err, schedule.ErrNoMatch) {
+		t.Errorf("Timer.Next() error = %v, want %v", err, schedule.ErrNoMatch)
+	}
+}
+
+func TestParse_Describe(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{expr: "30 3 * * 1", want: "every Monday at 03:30 UTC"},
+		{expr: "0 9 * * MON-FRI", want: "every weekday at 09:00 UTC"},
+		{expr: "*/15 * * * *", want: "every 15 minutes UTC"},
+		{expr: "0 0 1,15 * *", want: "the 1st and 15th of every month at 00:00 UTC"},
+		{expr: "0 0 13 * FRI", want: "the 13th of every month and every Friday at 00:00 UTC"},
+		{expr: "0 12 * 6-8 *", want: "every day in June, July and August at 12:00 UTC"},
+		{expr: "0 18 * * 5L", want: "the last Friday of every month at 18:00 UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := s.(*schedule.Timer).Describe(); got != tt.want {
+				t.Errorf("Timer.Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// BackoffJitter is the random spread of the delays of a Backoff scheduler,
+// as described in https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+type BackoffJitter int
+
+const (
+	// NoJitter waits for the exact delay of each attempt.
+	NoJitter BackoffJitter = iota
+	// FullJitter waits for a random delay between zero and the delay of the attempt.
+	FullJitter
+	// EqualJitter waits for half of the delay of the attempt, plus a random delay up to the other half.
+	EqualJitter
+	// DecorrelatedJitter waits for a random delay between the initial delay and the previous delay times the multiplier.
+	DecorrelatedJitter
+)
+
+// String returns the name of the backoff jitter.
+func (j BackoffJitter) String() string {
+	switch j {
+	case NoJitter:
+		return "no jitter"
+	case FullJitter:
+		return "full jitter"
+	case EqualJitter:
+		return "equal jitter"
+	case DecorrelatedJitter:
+		return "decorrelated jitter"
+	}
+	return fmt.Sprintf("BackoffJitter(%d)", int(j))
+}
+
+// Backoff is a scheduler whose delays grow exponentially from one run to the next, eg: to retry a failing call.
+// Each call to Next returns a copy of the scheduler moved to its next attempt.
+//
+// eg:
+//	...
+//	b := schedule.ByBackoff().SetInitialDelay(time.Second).SetMaxDelay(time.Minute).SetMaxAttempts(10)
+//	var s schedule.Scheduler = &b
+//	for {
+//		if s, err = s.Next(); err != nil {
+//			return err   // will give up after the 10th attempt.
+//		}
+//		time.Sleep(s.(*schedule.Backoff).Delay())   // will wait 1s, 2s, 4s ... up to a minute.
+//		...
+//	}
+//	...
+type Backoff struct {
+	schedule
+	initial     time.Duration // delay before the first attempt.
+	multiplier  float64       // growth of the delay from one attempt to the next.
+	max         time.Duration // longest delay, 0 for no limit.
+	mode        BackoffJitter // random spread of the delays.
+	maxAttempts int           // number of attempts after which the scheduler stops, 0 for no limit.
+	attempt     int           // number of attempts scheduled so far.
+	delay       time.Duration // delay before the last attempt scheduled.
+}
+
+// ByBackoff returns a new exponential backoff scheduler.
+// It starts with a delay of a second, doubled on every attempt, without limit.
+func ByBackoff(ctx ...context.Context) *Backoff {
+	return &Backoff{
+		schedule:   newSched(true, setCtx(ctx), systemClock),
+		initial:    time.Second,
+		multiplier: 2,
+	}
+}
+
+// SetInitialDelay sets the delay before the first attempt.
+//
+// eg:
+//	...
+//	b.SetInitialDelay(100 * time.Millisecond)   // will wait 100ms before the first attempt.
+//	...
+func (b Backoff) SetInitialDelay(d time.Duration) Backoff {
+	if d <= 0 {
+		b.invalid("initial delay must be positive, given %s", d)
+		return b
+	}
+	b.initial = d
+	return b
+}
+
+// SetMultiplier sets the growth of the delay from one attempt to the next.
+//
+// eg:
+//	...
+//	b.SetMultiplier(1.5)   // will wait 1.5 times longer on every attempt.
+//	...
+func (b Backoff) SetMultiplier(m float64) Backoff {
+	if m < 1 || math.IsInf(m, 0) || math.IsNaN(m) {
+		b.invalid("multiplier must be at least 1, given %g", m)
+		return b
+	}
+	b.multiplier = m
+	return b
+}
+
+// SetMaxDelay sets the longest delay between two attempts, 0 for no limit.
+//
+// eg:
+//	...
+//	b.SetMaxDelay(time.Minute)   // will never wait longer than a minute.
+//	...
+func (b Backoff) SetMaxDelay(d time.Duration) Backoff {
+	if d < 0 {
+		b.invalid("max delay must not be negative, given %s", d)
+		return b
+	}
+	b.max = d
+	return b
+}
+
+// SetBackoffJitter sets the random spread of the delays.
+//
+// eg:
+//	...
+//	b.SetBackoffJitter(schedule.FullJitter)   // will wait a random delay up to the delay of the attempt.
+//	...
+func (b Backoff) SetBackoffJitter(j BackoffJitter) Backoff {
+	if j < NoJitter || j > DecorrelatedJitter {
+		b.invalid("unknown backoff jitter %d", int(j))
+		return b
+	}
+	b.mode = j
+	return b
+}
+
+// SetJitterSource sets the source of the random delays, eg: a seeded source in tests.
+// The source is not safe for concurrent use, the default source of math/rand is used when none is set.
+//
+// eg:
+//	...
+//	b.SetBackoffJitter(schedule.FullJitter).SetJitterSource(rand.NewSource(42))   // will wait the same delays on every start.
+//	...
+func (b Backoff) SetJitterSource(src rand.Source) Backoff {
+	b.jitter.rand = rand.New(src)
+	return b
+}
+
+// SetMaxAttempts sets the number of attempts after which the scheduler stops, 0 for no limit.
+//
+// eg:
+//	...
+//	b.SetMaxAttempts(5)   // will fail with ErrScheduleExhausted on the 6th call to Next.
+//	...
+func (b Backoff) SetMaxAttempts(n int) Backoff {
+	if n < 0 {
+		b.invalid("max attempts must not be negative, given %d", n)
+		return b
+	}
+	b.maxAttempts = n
+	return b
+}
+
+// SetClock sets the clock of the scheduler, which is used to tell the current time.
+// The system clock is used by default.
+//
+// eg:
+//	...
+//	b.SetClock(schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func (b Backoff) SetClock(c Clock) Backoff {
+	b.setClock(c)
+	return b
+}
+
+// Reset returns the scheduler back to its first attempt, starting from the current time, eg: after a successful call.
+func (b Backoff) Reset() Backoff {
+	b.attempt, b.delay, b.interval = 0, 0, 0
+	b.timer = b.timeSource().Now()
+	return b
+}
+
+// Delay returns the delay before the attempt scheduled by the last call to Next.
+func (b *Backoff) Delay() time.Duration {
+	return b.delay
+}
+
+// Attempt returns the number of attempts scheduled so far, 1 after the first call to Next.
+func (b *Backoff) Attempt() int {
+	return b.attempt
+}
+
+// Next schedules the next attempt, returning the scheduler moved to it.
+// It fails with ErrScheduleExhausted once the max attempts are scheduled.
+func (b Backoff) Next() (Scheduler, error) {
+	if err := b.check(b.attempt); err != nil {
+		return nil, err
+	}
+
+	b.delay = b.spreadDelay(b.attempt, b.delay)
+	b.attempt++
+	b.interval = b.delay
+	b.timer = b.timeSource().Now().Add(b.delay)
+	return &b, nil
+}
+
+// NextAfter returns the time of the first attempt after t, of the attempts following the last one scheduled
+// with Next. The delays are taken without jitter, which only applies to the attempts scheduled with Next.
+// The attempts at a steady delay are skipped at once, while the ones at a growing delay are stepped through,
+// up to maxCandidates of them: it fails with ErrNoMatch for a t further than that.
+func (b Backoff) NextAfter(t time.Time) (time.Time, error) {
+	at := b.timer
+	for k, grown := b.attempt, 0; ; k++ {
+		if err := b.check(k); err != nil {
+			return time.Time{}, err
+		}
+		d := b.base(k)
+		at = at.Add(d)
+		if at.After(t) {
+			return at, nil
+		}
+
+		// once the delay stops growing, jump straight to the last attempt before t, or the last attempt allowed.
+		if b.multiplier == 1 || d == b.max || d == math.MaxInt64 {
+			n := int(t.Sub(at) / d)
+			if b.maxAttempts > 0 && n > b.maxAttempts-k-1 {
+				n = b.maxAttempts - k - 1
+			}
+			at, k = at.Add(time.Duration(n)*d), k+n
+			continue
+		}
+		if grown++; grown == maxCandidates {
+			return time.Time{}, fmt.Errorf("%w: no attempt after %s within %d growing delays", ErrNoMatch, t.Format(time.RFC3339), maxCandidates)
+		}
+	}
+}
+
+// check reports an error when the scheduler is invalid or when the given attempt is beyond the max attempts.
+func (b *Backoff) check(attempt int) error {
+	if b.dur.err != nil {
+		return b.dur.err
+	}
+	if b.maxAttempts > 0 && attempt >= b.maxAttempts {
+		return fmt.Errorf("%w: backoff gave up after %d attempts", ErrScheduleExhausted, b.maxAttempts)
+	}
+	return nil
+}
+
+// base returns the delay before the given attempt, counted from 0, without jitter.
+func (b *Backoff) base(attempt int) time.Duration {
+	d := float64(b.initial) * math.Pow(b.multiplier, float64(attempt))
+	return b.limit(d)
+}
+
+// limit returns the delay capped by the max delay, and by the longest duration.
+func (b *Backoff) limit(d float64) time.Duration {
+	if b.max > 0 && d > float64(b.max) {
+		return b.max
+	}
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
+
+// spreadDelay returns the delay before the given attempt, spread with the jitter of the scheduler.
+// prev is the delay before the previous attempt.
+func (b *Backoff) spreadDelay(attempt int, prev time.Duration) time.Duration {
+	d := b.base(attempt)
+	switch b.mode {
+	case FullJitter:
+		return b.upTo(d)
+	case EqualJitter:
+		return d/2 + b.upTo(d-d/2)
+	case DecorrelatedJitter:
+		if prev < b.initial {
+			prev = b.initial
+		}
+		hi := b.limit(float64(prev) * b.multiplier)
+		if hi <= b.initial {
+			return hi
+		}
+		return b.initial + b.upTo(hi-b.initial)
+	}
+	return d
+}
+
+// upTo returns a random delay within [0, d], or [0, d) for the longest duration which cannot be exceeded by one.
+func (b *Backoff) upTo(d time.Duration) time.Duration {
+	n := int64(d)
+	if n < math.MaxInt64 {
+		n++
+	}
+	return time.Duration(b.jitter.int63n(n))
+}
+
+// String returns the settings of the scheduler and the delay before its next attempt.
+// eg:
+//	...
+//	b.SetInitialDelay(time.Second).SetMaxDelay(time.Minute).SetMaxAttempts(10).SetBackoffJitter(schedule.FullJitter)
+//	fmt.Println(b.String()) // will print: 1s x2 up to 1m0s, full jitter, attempt 0 of 10 -> next execution in 0s
+//	...
+func (b *Backoff) String() string {
+	max, attempts := "no limit", "no limit"
+	if b.max > 0 {
+		max = b.max.String()
+	}
+	if b.maxAttempts > 0 {
+		attempts = strconv.Itoa(b.maxAttempts)
+	}
+	return fmt.Sprintf("%s x%s up to %s, %s, attempt %d of %s -> next execution in %s",
+		b.initial, strconv.FormatFloat(b.multiplier, 'f', -1, 64), max, b.mode, b.attempt, attempts, b.interval)
+}
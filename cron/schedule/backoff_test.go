@@ -0,0 +1,241 @@
+package schedule
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// delays returns the delays of the next n attempts of the scheduler.
+func delays(t *testing.T, b Backoff, n int) []time.Duration {
+	t.Helper()
+	var got []time.Duration
+	var s Scheduler = &b
+	for i := 0; i < n; i++ {
+		var err error
+		if s, err = s.Next(); err != nil {
+			t.Fatalf("Backoff.Next() error = %v", err)
+		}
+		got = append(got, s.(*Backoff).Delay())
+	}
+	return got
+}
+
+func TestBackoff_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Backoff
+		n    int
+		want []time.Duration
+	}{
+		{
+			name: "defaults",
+			b:    *ByBackoff(),
+			n:    4,
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		}, {
+			name: "multiplier and max delay",
+			b:    ByBackoff().SetInitialDelay(100 * time.Millisecond).SetMultiplier(3).SetMaxDelay(time.Second),
+			n:    5,
+			want: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second, time.Second},
+		}, {
+			name: "constant",
+			b:    ByBackoff().SetInitialDelay(time.Minute).SetMultiplier(1),
+			n:    3,
+			want: []time.Duration{time.Minute, time.Minute, time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := delays(t, tt.b, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Backoff.Delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff_Next_jitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   BackoffJitter
+		within func(attempt int, prev time.Duration) (lo, hi time.Duration)
+	}{
+		{
+			name: "full",
+			mode: FullJitter,
+			within: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				return 0, time.Second << attempt
+			},
+		}, {
+			name: "equal",
+			mode: EqualJitter,
+			within: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				return (time.Second << attempt) / 2, time.Second << attempt
+			},
+		}, {
+			name: "decorrelated",
+			mode: DecorrelatedJitter,
+			within: func(attempt int, prev time.Duration) (time.Duration, time.Duration) {
+				if prev < time.Second {
+					prev = time.Second
+				}
+				return time.Second, 2 * prev
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := ByBackoff().SetBackoffJitter(tt.mode).SetJitterSource(rand.NewSource(1))
+			got := delays(t, b, 8)
+			var prev time.Duration
+			for i, d := range got {
+				if lo, hi := tt.within(i, prev); d < lo || d > hi {
+					t.Errorf("Backoff.Delay() of attempt %d = %v, want within %v and %v", i+1, d, lo, hi)
+				}
+				prev = d
+			}
+
+			// the same seed gives the same delays.
+			again := delays(t, ByBackoff().SetBackoffJitter(tt.mode).SetJitterSource(rand.NewSource(1)), 8)
+			if !reflect.DeepEqual(got, again) {
+				t.Errorf("Backoff.Delay() = %v, want %v with the same seed", again, got)
+			}
+		})
+	}
+}
+
+func TestBackoff_Next_unbounded(t *testing.T) {
+	for _, mode := range []BackoffJitter{NoJitter, FullJitter, EqualJitter, DecorrelatedJitter} {
+		t.Run(mode.String(), func(t *testing.T) {
+			// without max delay, the delays grow up to the longest duration, which the jitter must not overflow.
+			got := delays(t, ByBackoff().SetBackoffJitter(mode).SetJitterSource(rand.NewSource(1)), 100)
+			for i, d := range got {
+				if d < 0 {
+					t.Errorf("Backoff.Delay() of attempt %d = %v, want positive", i+1, d)
+				}
+			}
+			if mode == NoJitter && got[99] != math.MaxInt64 {
+				t.Errorf("Backoff.Delay() of attempt 100 = %v, want %v", got[99], time.Duration(math.MaxInt64))
+			}
+		})
+	}
+}
+
+func TestBackoff_SetMaxAttempts(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	var s Scheduler
+	b := ByBackoff().SetClock(c).SetMaxAttempts(2)
+	s = &b
+
+	for i := 1; i <= 2; i++ {
+		var err error
+		if s, err = s.Next(); err != nil {
+			t.Fatalf("Backoff.Next() error = %v", err)
+		}
+		if got := s.(*Backoff).Attempt(); got != i {
+			t.Errorf("Backoff.Attempt() = %d, want %d", got, i)
+		}
+	}
+	if _, err := s.Next(); !errors.Is(err, ErrScheduleExhausted) {
+		t.Errorf("Backoff.Next() error = %v, want %v after the max attempts", err, ErrScheduleExhausted)
+	}
+
+	reset := s.(*Backoff).Reset()
+	if _, err := reset.Next(); err != nil {
+		t.Errorf("Backoff.Next() error = %v after Reset", err)
+	}
+}
+
+func TestBackoff_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Backoff
+	}{
+		{name: "initial delay", b: ByBackoff().SetInitialDelay(0)},
+		{name: "multiplier", b: ByBackoff().SetMultiplier(0.5)},
+		{name: "max delay", b: ByBackoff().SetMaxDelay(-time.Second)},
+		{name: "jitter", b: ByBackoff().SetBackoffJitter(BackoffJitter(9))},
+		{name: "max attempts", b: ByBackoff().SetMaxAttempts(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.b.Next(); err == nil {
+				t.Errorf("Backoff.Next() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestBackoff_NextAfter(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	b := ByBackoff().SetClock(c).SetMaxDelay(8 * time.Second)
+
+	got, err := NextN(&b, start, 6)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	want := []time.Time{
+		start.Add(time.Second),
+		start.Add(3 * time.Second),
+		start.Add(7 * time.Second),
+		start.Add(15 * time.Second),
+		start.Add(23 * time.Second),
+		start.Add(31 * time.Second),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NextN() = %v, want %v", got, want)
+	}
+
+	// the runs after the max delay is reached keep to steps of the max delay.
+	if got, err := b.NextAfter(start.Add(time.Hour)); err != nil || !got.Equal(start.Add(time.Hour+7*time.Second)) {
+		t.Errorf("Backoff.NextAfter() = %v, %v, want %v", got, err, start.Add(time.Hour+7*time.Second))
+	}
+	if _, err := b.SetMaxAttempts(3).NextAfter(start.Add(7 * time.Second)); err == nil {
+		t.Errorf("Backoff.NextAfter() error = nil, want error after the max attempts")
+	}
+	if _, err := b.SetMaxAttempts(100).NextAfter(start.Add(time.Hour)); !errors.Is(err, ErrScheduleExhausted) {
+		t.Errorf("Backoff.NextAfter() error = %v, want %v after the max attempts", err, ErrScheduleExhausted)
+	}
+
+	// a steady delay is skipped at once, however far t is.
+	far := start.AddDate(200, 0, 0)
+	steady := ByBackoff().SetClock(c).SetInitialDelay(time.Second).SetMultiplier(1)
+	if got, err := steady.NextAfter(far); err != nil || !got.Equal(far.Add(time.Second)) {
+		t.Errorf("Backoff.NextAfter() = %v, %v, want %v", got, err, far.Add(time.Second))
+	}
+
+	// a delay growing too slowly to reach t within maxCandidates attempts is given up on.
+	slow := ByBackoff().SetClock(c).SetInitialDelay(time.Millisecond).SetMultiplier(1.0001)
+	if _, err := slow.NextAfter(far); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Backoff.NextAfter() error = %v, want %v", err, ErrNoMatch)
+	}
+}
+
+func TestBackoff_String(t *testing.T) {
+	tests := []struct {
+		name string
+		b    Backoff
+		want string
+	}{
+		{
+			name: "defaults",
+			b:    *ByBackoff(),
+			want: "1s x2 up to no limit, no jitter, attempt 0 of no limit -> next execution in 0s",
+		}, {
+			name: "limits",
+			b:    ByBackoff().SetMultiplier(1.5).SetMaxDelay(time.Minute).SetMaxAttempts(10).SetBackoffJitter(FullJitter),
+			want: "1s x1.5 up to 1m0s, full jitter, attempt 0 of 10 -> next execution in 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.String(); got != tt.want {
+				t.Errorf("Backoff.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ErrScheduleExhausted is returned once a scheduler has no run left, as it is past its end or has run its
+// maximum number of times, see EndAt and MaxRuns.
+var ErrScheduleExhausted = errors.New("schedule is exhausted")
+
+// setStart sets the time before which the scheduler does not run.
+func (s *schedule) setStart(t time.Time) {
+	if !s.end.IsZero() && !t.Before(s.end) {
+		s.invalid("start %s must be before the end %s", t.Format(time.RFC3339), s.end.Format(time.RFC3339))
+		return
+	}
+	s.start = t
+}
+
+// setEnd sets the time after which the scheduler does not run.
+func (s *schedule) setEnd(t time.Time) {
+	if !s.start.IsZero() && !t.After(s.start) {
+		s.invalid("end %s must be after the start %s", t.Format(time.RFC3339), s.start.Format(time.RFC3339))
+		return
+	}
+	s.end = t
+}
+
+// setMaxRuns sets the number of times the scheduler runs, counting the runs armed with Next.
+func (s *schedule) setMaxRuns(n int) {
+	if n < 1 {
+		s.invalid("maximum number of runs must be at least 1, given %d", n)
+		return
+	}
+	s.maxRuns = n
+	s.fired = new(int64)
+}
+
+// boundNext returns the first run after t found by nextAfter, within the start and the end of the scheduler.
+func (s *schedule) boundNext(t time.Time, nextAfter func(time.Time) (time.Time, error)) (time.Time, error) {
+	if !s.start.IsZero() && t.Before(s.start) {
+		// a run at the start is the first run of the scheduler.
+		t = s.start.Add(-time.Nanosecond)
+	}
+	if !s.end.IsZero() && !t.Before(s.end) {
+		return time.Time{}, ErrScheduleExhausted
+	}
+	next, err := nextAfter(t)
+	if err != nil {
+		return next, err
+	}
+	if !s.end.IsZero() && next.After(s.end) {
+		return time.Time{}, ErrScheduleExhausted
+	}
+	return next, nil
+}
+
+// boundPrev returns the last run before t found by prevBefore, within the start and the end of the scheduler.
+func (s *schedule) boundPrev(t time.Time, prevBefore func(time.Time) (time.Time, error)) (time.Time, error) {
+	if !s.end.IsZero() && t.After(s.end) {
+		// a run at the end is the last run of the scheduler.
+		t = s.end.Add(time.Nanosecond)
+	}
+	if !s.start.IsZero() && !t.After(s.start) {
+		return time.Time{}, fmt.Errorf("%w before %s, the scheduler starts at %s", ErrNoMatch, t.Format(time.RFC3339), s.start.Format(time.RFC3339))
+	}
+	prev, err := prevBefore(t)
+	if err != nil {
+		return prev, err
+	}
+	if !s.start.IsZero() && prev.Before(s.start) {
+		return time.Time{}, fmt.Errorf("%w before %s, the scheduler starts at %s", ErrNoMatch, t.Format(time.RFC3339), s.start.Format(time.RFC3339))
+	}
+	return prev, nil
+}
+
+// countRun counts a run armed with Next, failing with ErrScheduleExhausted once the scheduler has run its maximum number of times.
+// The count is shared by the copies of the scheduler.
+func (s *schedule) countRun() error {
+	if s.maxRuns == 0 {
+		return nil
+	}
+	if s.fired == nil {
+		s.fired = new(int64)
+	}
+	if atomic.AddInt64(s.fired, 1) > int64(s.maxRuns) {
+		atomic.AddInt64(s.fired, -1)
+		return ErrScheduleExhausted
+	}
+	return nil
+}
+
+// uncountRun gives back a run counted with countRun which did not happen.
+func (s *schedule) uncountRun() {
+	if s.maxRuns > 0 && s.fired != nil {
+		atomic.AddInt64(s.fired, -1)
+	}
+}
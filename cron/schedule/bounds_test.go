@@ -0,0 +1,143 @@
+package schedule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimer_bounds(t *testing.T) {
+	at := func(d int) time.Time { return time.Date(2020, time.January, d, 9, 0, 0, 0, time.UTC) }
+	daily := func() Timer { return ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0) }
+
+	tests := []struct {
+		name    string
+		timer   Timer
+		after   time.Time
+		n       int
+		want    []time.Time
+		wantErr error
+	}{
+		{
+			name:  "start",
+			timer: daily().StartAt(at(10).Add(-time.Hour)),
+			after: at(1),
+			n:     2,
+			want:  []time.Time{at(10), at(11)},
+		}, {
+			name:  "start on a run",
+			timer: daily().StartAt(at(10)),
+			after: at(1),
+			n:     1,
+			want:  []time.Time{at(10)},
+		}, {
+			name:    "end",
+			timer:   daily().EndAt(at(3)),
+			after:   at(1),
+			n:       5,
+			want:    []time.Time{at(2), at(3)},
+			wantErr: ErrScheduleExhausted,
+		}, {
+			name:    "start and end",
+			timer:   daily().StartAt(at(5)).EndAt(at(6).Add(time.Hour)),
+			after:   at(1),
+			n:       5,
+			want:    []time.Time{at(5), at(6)},
+			wantErr: ErrScheduleExhausted,
+		}, {
+			name:    "after the end",
+			timer:   daily().EndAt(at(3)),
+			after:   at(3),
+			n:       1,
+			want:    []time.Time{},
+			wantErr: ErrScheduleExhausted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextN(&tt.timer, tt.after, tt.n)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NextN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_bounds_Prev(t *testing.T) {
+	at := func(d int) time.Time { return time.Date(2020, time.January, d, 9, 0, 0, 0, time.UTC) }
+	tr := ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0).StartAt(at(5)).EndAt(at(10))
+
+	if got, err := tr.Prev(at(20)); err != nil || !got.Equal(at(10)) {
+		t.Errorf("Timer.Prev() = %v, %v, want %v", got, err, at(10))
+	}
+	if got, err := tr.Prev(at(5)); err == nil {
+		t.Errorf("Timer.Prev() = %v, want an error before the start", got)
+	}
+}
+
+func TestTimer_MaxRuns(t *testing.T) {
+	clk := NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	tr := ByTimestamp(true).SetMinutes(0).SetSeconds(0).SetClock(clk).MaxRuns(2)
+	var s Scheduler = &tr
+
+	// the runs are counted across the copies returned by Next.
+	for run := 1; run <= 2; run++ {
+		var err error
+		if s, err = s.Next(); err != nil {
+			t.Fatalf("Timer.Next() run %d error = %v", run, err)
+		}
+		clk.Advance(time.Hour)
+	}
+	if _, err := s.Next(); !errors.Is(err, ErrScheduleExhausted) {
+		t.Errorf("Timer.Next() error = %v, want %v", err, ErrScheduleExhausted)
+	}
+}
+
+func TestInterval_bounds(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC)
+	i := ByFreq(true).AddHour(2).StartAt(start).EndAt(start.Add(5 * time.Hour))
+
+	got, err := NextN(&i, start.Add(-time.Hour), 5)
+	want := []time.Time{start, start.Add(2 * time.Hour), start.Add(4 * time.Hour)}
+	if !errors.Is(err, ErrScheduleExhausted) || !reflect.DeepEqual(got, want) {
+		t.Errorf("NextN() = %v, %v, want %v, %v", got, err, want, ErrScheduleExhausted)
+	}
+	if prev, err := i.Prev(start.Add(24 * time.Hour)); err != nil || !prev.Equal(start.Add(4*time.Hour)) {
+		t.Errorf("Interval.Prev() = %v, %v, want %v", prev, err, start.Add(4*time.Hour))
+	}
+}
+
+func TestInterval_MaxRuns(t *testing.T) {
+	clk := NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	i := ByFreq(true).AddMinute(10).SetClock(clk).MaxRuns(1)
+	s, err := i.Next()
+	if err != nil {
+		t.Fatalf("Interval.Next() error = %v", err)
+	}
+	if _, err := s.Next(); !errors.Is(err, ErrScheduleExhausted) {
+		t.Errorf("Interval.Next() error = %v, want %v", err, ErrScheduleExhausted)
+	}
+}
+
+func Test_schedule_bounds_invalid(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		s    func() Previewer
+	}{
+		{name: "end before start", s: func() Previewer { t := ByTimestamp(true).SetHours(9).StartAt(start).EndAt(start); return &t }},
+		{name: "start after end", s: func() Previewer { i := ByFreq(true).AddHour(1).EndAt(start).StartAt(start.Add(time.Hour)); return &i }},
+		{name: "no runs", s: func() Previewer { t := ByTimestamp(true).SetHours(9).MaxRuns(0); return &t }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.s().NextAfter(start); err == nil {
+				t.Errorf("NextAfter() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Calendar holds the dates and time ranges on which a scheduler must not run, eg: exchange holidays or freeze windows.
+// A scheduler given a calendar skips its excluded runs, moving on to its first run which is not excluded.
+// A calendar is shared by the schedulers it is given to, and must not be changed while they are in use.
+//
+// eg:
+//	...
+//	cal := schedule.NewCalendar(nyc).ExcludeDate(2020, schedule.December, 25)
+//	if err := cal.ImportICSFile("nyse-holidays.ics"); err != nil {
+//		...
+//	}
+//	t := schedule.ByTimestamp(true).SetHours(16).SetMinutes(0).SetSeconds(0).SetCalendar(cal)   // will not run on holidays.
+//	...
+type Calendar struct {
+	location *time.Location // location of the excluded dates, and of the floating times of the imported events.
+	ranges   []blackout     // excluded time ranges, sorted and merged.
+	err      error          // first invalid range given to the calendar, reported when scheduling.
+}
+
+// blackout is a time range on which a scheduler must not run, from start included to end excluded.
+type blackout struct {
+	start, end time.Time
+}
+
+// NewCalendar returns an empty calendar whose excluded dates are days of the given location, UTC when nil.
+func NewCalendar(loc *time.Location) *Calendar {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Calendar{location: loc}
+}
+
+// ExcludeDate excludes the whole day of the given date, from midnight to midnight in the location of the calendar.
+//
+// eg:
+//	...
+//	cal.ExcludeDate(2020, schedule.December, 25)   // will not run the schedulers on Christmas Day.
+//	...
+func (c *Calendar) ExcludeDate(year int, month Month, day int) *Calendar {
+	start := time.Date(year, time.Month(month), day, 0, 0, 0, 0, c.location)
+	if start.Day() != day || start.Month() != time.Month(month) {
+		c.invalid("date %04d-%02d-%02d does not exist", year, int(month), day)
+		return c
+	}
+	c.add(start, start.AddDate(0, 0, 1))
+	return c
+}
+
+// ExcludeRange excludes the time range from start included to end excluded.
+//
+// eg:
+//	...
+//	cal.ExcludeRange(freeze, freeze.Add(48 * time.Hour))   // will not run the schedulers during the 2 days of the freeze.
+//	...
+func (c *Calendar) ExcludeRange(start, end time.Time) *Calendar {
+	if !end.After(start) {
+		c.invalid("range end %s must be after its start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+		return c
+	}
+	c.add(start, end)
+	return c
+}
+
+// Excludes reports whether the calendar excludes the given time.
+func (c *Calendar) Excludes(t time.Time) bool {
+	_, ok := c.blackoutAt(t)
+	return ok
+}
+
+// invalid records an invalid value given to the calendar, to be reported when scheduling as an error wrapping ErrInvalid.
+// Only the first invalid value is kept.
+func (c *Calendar) invalid(format string, args ...interface{}) {
+	if c.err == nil {
+		c.err = fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+	}
+}
+
+// add excludes the time range, merging it with the ranges it overlaps or touches.
+func (c *Calendar) add(start, end time.Time) {
+	i := sort.Search(len(c.ranges), func(i int) bool { return !c.ranges[i].end.Before(start) })
+	j := i
+	for ; j < len(c.ranges) && !c.ranges[j].start.After(end); j++ {
+		if c.ranges[j].start.Before(start) {
+			start = c.ranges[j].start
+		}
+		if c.ranges[j].end.After(end) {
+			end = c.ranges[j].end
+		}
+	}
+	merged := append([]blackout{{start: start, end: end}}, c.ranges[j:]...)
+	c.ranges = append(c.ranges[:i], merged...)
+}
+
+// blackoutAt returns the excluded range containing t, if any.
+func (c *Calendar) blackoutAt(t time.Time) (blackout, bool) {
+	if c == nil {
+		return blackout{}, false
+	}
+	i := sort.Search(len(c.ranges), func(i int) bool { return c.ranges[i].end.After(t) })
+	if i < len(c.ranges) && !c.ranges[i].start.After(t) {
+		return c.ranges[i], true
+	}
+	return blackout{}, false
+}
+
+// skip returns the first run which is not excluded, starting with next.
+// nextAfter returns the first run of the scheduler after the given time.
+func (c *Calendar) skip(next time.Time, nextAfter func(time.Time) (time.Time, error)) (time.Time, error) {
+	if c == nil {
+		return next, nil
+	}
+	if c.err != nil {
+		return time.Time{}, c.err
+	}
+	for attemptsRem := maxCandidates; attemptsRem > 0; attemptsRem-- {
+		b, ok := c.blackoutAt(next)
+		if !ok {
+			return next, nil
+		}
+		var err error
+		if next, err = nextAfter(b.end.Add(-time.Nanosecond)); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: every run within %d candidates is excluded by the calendar", ErrNoMatch, maxCandidates)
+}
+
+// skipBack returns the last run which is not excluded, starting with prev.
+// prevBefore returns the last run of the scheduler before the given time.
+func (c *Calendar) skipBack(prev time.Time, prevBefore func(time.Time) (time.Time, error)) (time.Time, error) {
+	if c == nil {
+		return prev, nil
+	}
+	if c.err != nil {
+		return time.Time{}, c.err
+	}
+	for attemptsRem := maxCandidates; attemptsRem > 0; attemptsRem-- {
+		b, ok := c.blackoutAt(prev)
+		if !ok {
+			return prev, nil
+		}
+		var err error
+		if prev, err = prevBefore(b.start); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: every run within %d candidates is excluded by the calendar", ErrNoMatch, maxCandidates)
+}
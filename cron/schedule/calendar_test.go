@@ -0,0 +1,170 @@
+package schedule
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalendar_add(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2020, time.January, 1, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		ranges [][2]int
+		want   []blackout
+	}{
+		{
+			name:   "sorted",
+			ranges: [][2]int{{5, 6}, {1, 2}, {3, 4}},
+			want:   []blackout{{at(1), at(2)}, {at(3), at(4)}, {at(5), at(6)}},
+		}, {
+			name:   "overlapping",
+			ranges: [][2]int{{1, 3}, {2, 5}, {8, 9}, {0, 1}},
+			want:   []blackout{{at(0), at(5)}, {at(8), at(9)}},
+		}, {
+			name:   "touching",
+			ranges: [][2]int{{1, 2}, {3, 4}, {2, 3}},
+			want:   []blackout{{at(1), at(4)}},
+		}, {
+			name:   "covering",
+			ranges: [][2]int{{2, 3}, {4, 5}, {6, 7}, {1, 8}},
+			want:   []blackout{{at(1), at(8)}},
+		}, {
+			name:   "within",
+			ranges: [][2]int{{1, 8}, {2, 3}},
+			want:   []blackout{{at(1), at(8)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalendar(nil)
+			for _, r := range tt.ranges {
+				c.ExcludeRange(at(r[0]), at(r[1]))
+			}
+			if !reflect.DeepEqual(c.ranges, tt.want) {
+				t.Errorf("Calendar.ranges = %v, want %v", c.ranges, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_Excludes(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	c := NewCalendar(berlin).
+		ExcludeDate(2020, December, 25).
+		ExcludeRange(time.Date(2020, time.July, 1, 22, 0, 0, 0, time.UTC), time.Date(2020, time.July, 2, 6, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{name: "start of the date", t: time.Date(2020, time.December, 25, 0, 0, 0, 0, berlin), want: true},
+		{name: "end of the date", t: time.Date(2020, time.December, 25, 23, 59, 59, 0, berlin), want: true},
+		{name: "day before in the location", t: time.Date(2020, time.December, 24, 23, 30, 0, 0, time.UTC), want: true},
+		{name: "day after", t: time.Date(2020, time.December, 26, 0, 0, 0, 0, berlin), want: false},
+		{name: "start of the range", t: time.Date(2020, time.July, 1, 22, 0, 0, 0, time.UTC), want: true},
+		{name: "end of the range", t: time.Date(2020, time.July, 2, 6, 0, 0, 0, time.UTC), want: false},
+		{name: "before the range", t: time.Date(2020, time.July, 1, 21, 59, 59, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Excludes(tt.t); got != tt.want {
+				t.Errorf("Calendar.Excludes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_invalid(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		c    *Calendar
+	}{
+		{name: "date", c: NewCalendar(nil).ExcludeDate(2021, February, 29)},
+		{name: "empty range", c: NewCalendar(nil).ExcludeRange(start, start)},
+		{name: "reversed range", c: NewCalendar(nil).ExcludeRange(start, start.Add(-time.Hour))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := ByTimestamp(true).SetMinutes(0).SetSeconds(0).SetCalendar(tt.c)
+			if _, err := tr.NextAfter(start); err == nil {
+				t.Errorf("Timer.NextAfter() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTimer_SetCalendar(t *testing.T) {
+	holidays := NewCalendar(nil).ExcludeDate(2020, December, 25).ExcludeDate(2020, December, 28)
+	tr := ByTimestamp(true).SetDays(Monday, Tuesday, Wednesday, Thursday, Friday).SetHours(16).SetMinutes(0).SetSeconds(0).SetCalendar(holidays)
+	day := func(d int) time.Time { return time.Date(2020, time.December, d, 16, 0, 0, 0, time.UTC) }
+
+	got, err := NextN(&tr, day(23), 3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if want := []time.Time{day(24), day(29), day(30)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Timer.NextAfter() = %v, want %v", got, want)
+	}
+
+	if got, err := tr.Prev(day(29)); err != nil || !got.Equal(day(24)) {
+		t.Errorf("Timer.Prev() = %v, %v, want %v", got, err, day(24))
+	}
+
+	c := NewFakeClock(day(24))
+	s, err := tr.SetClock(c).SetJitter(time.Hour).SetJitterSource(rand.NewSource(1)).Next()
+	if err != nil {
+		t.Fatalf("Timer.Next() error = %v", err)
+	}
+	if next := s.(*Timer).timer; holidays.Excludes(next) || next.Before(day(29).Add(-time.Hour)) {
+		t.Errorf("Timer.Next() = %v, want a run around %v", next, day(29))
+	}
+}
+
+func TestInterval_SetCalendar(t *testing.T) {
+	midnight := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	freeze := NewCalendar(nil).ExcludeRange(midnight.Add(2*time.Hour+30*time.Minute), midnight.Add(4*time.Hour+15*time.Minute))
+
+	tests := []struct {
+		name  string
+		i     Interval
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "anchored",
+			i:     ByFreq(true).AddHour(1).SetAnchor(midnight).SetCalendar(freeze),
+			after: midnight.Add(2 * time.Hour),
+			want:  midnight.Add(5 * time.Hour),
+		}, {
+			name:  "not anchored",
+			i:     ByFreq(true).AddHour(1).SetCalendar(freeze),
+			after: midnight.Add(2 * time.Hour),
+			want:  midnight.Add(4*time.Hour + 15*time.Minute),
+		}, {
+			name:  "not excluded",
+			i:     ByFreq(true).AddHour(1).SetAnchor(midnight).SetCalendar(freeze),
+			after: midnight.Add(time.Hour),
+			want:  midnight.Add(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.NextAfter(tt.after)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("Interval.NextAfter() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	i := ByFreq(true).AddHour(1).SetAnchor(midnight).SetCalendar(freeze)
+	if got, err := i.Prev(midnight.Add(5 * time.Hour)); err != nil || !got.Equal(midnight.Add(2*time.Hour)) {
+		t.Errorf("Interval.Prev() = %v, %v, want %v", got, err, midnight.Add(2*time.Hour))
+	}
+	if got, err := i.SetAnchor(time.Time{}).Prev(midnight.Add(5 * time.Hour)); err != nil || !got.Equal(midnight.Add(2*time.Hour+30*time.Minute-time.Nanosecond)) {
+		t.Errorf("Interval.Prev() = %v, %v, want %v", got, err, midnight.Add(2*time.Hour+30*time.Minute-time.Nanosecond))
+	}
+}
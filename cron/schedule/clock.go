@@ -0,0 +1,76 @@
+package schedule
+
+import "time"
+
+// Clock is the source of time for the schedulers.
+// It allows the schedulers to be driven by virtual time, eg: FakeClock in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer creates a timer which sends the current time on its channel after at least duration d.
+	NewTimer(d time.Duration) ClockTimer
+	// NewTicker creates a ticker which sends the current time on its channel every duration d.
+	NewTicker(d time.Duration) ClockTicker
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// ClockTimer is a single event timer created by a Clock, modelled on time.Timer.
+type ClockTimer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// ClockTicker is a periodic timer created by a Clock, modelled on time.Ticker.
+type ClockTicker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// systemClock is the default clock of the schedulers, backed by the time pkg.
+var systemClock Clock = sysClock{}
+
+// sysClock implements Clock using the system time.
+type sysClock struct{}
+
+// Now always returns the current time in UTC.
+func (sysClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// NewTimer wraps time.NewTimer.
+func (sysClock) NewTimer(d time.Duration) ClockTimer {
+	return sysTimer{time.NewTimer(d)}
+}
+
+// NewTicker wraps time.NewTicker.
+func (sysClock) NewTicker(d time.Duration) ClockTicker {
+	return sysTicker{time.NewTicker(d)}
+}
+
+// After wraps time.After.
+func (sysClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// sysTimer implements ClockTimer using time.Timer.
+type sysTimer struct {
+	*time.Timer
+}
+
+// C returns the channel on which the time is delivered.
+func (t sysTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// sysTicker implements ClockTicker using time.Ticker.
+type sysTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel on which the ticks are delivered.
+func (t sysTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_sysClock(t *testing.T) {
+	c := systemClock
+
+	before := time.Now()
+	now := c.Now()
+	if now.Before(before.Truncate(time.Second)) || now.Location() != time.UTC {
+		t.Errorf("sysClock.Now() = %v, want current time in UTC", now)
+	}
+
+	timer := c.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Errorf("sysClock.NewTimer() did not fire")
+	}
+
+	ticker := c.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ticker.C():
+		case <-time.After(time.Second):
+			t.Errorf("sysClock.NewTicker() did not tick")
+		}
+	}
+
+	select {
+	case <-c.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Errorf("sysClock.After() did not fire")
+	}
+}
@@ -0,0 +1,238 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// setOp is the set operation a Composite scheduler applies to the runs of its schedulers.
+type setOp int
+
+const (
+	union setOp = iota
+	intersect
+	except
+)
+
+// String returns the name of the set operation.
+func (op setOp) String() string {
+	switch op {
+	case union:
+		return "union"
+	case intersect:
+		return "intersect"
+	case except:
+		return "except"
+	}
+	return fmt.Sprintf("setOp(%d)", int(op))
+}
+
+// maxCandidates is the number of candidate runs a Composite scheduler looks at before giving up on finding its next run.
+const maxCandidates = 10000
+
+// Composite is a scheduler whose runs are the union, the intersection or the difference of the runs of other schedulers.
+// The runs are compared as instants, eg: a run at 09:00 UTC of one scheduler is a run at 10:00 CET of the other.
+// Timer and Interval values are combined by pointer, as it is their pointer which is a Scheduler.
+//
+// eg:
+//	...
+//	every10 := schedule.ByFreq(true).AddMinute(10).SetAnchor(midnight)
+//	night := schedule.ByTimestamp(true).SetHours(2)
+//	c := schedule.Except(&every10, &night)   // will run every 10 minutes, except between 02:00 and 03:00.
+//	...
+type Composite struct {
+	schedule
+	op     setOp       // set operation applied to the runs of the schedulers.
+	scheds []Scheduler // schedulers combined, the first one being the base of the difference.
+}
+
+// Union returns a scheduler which runs whenever any of the given schedulers runs.
+//
+// eg:
+//	...
+//	c := schedule.Union(firstOfMonth, mondays)   // will run on the 1st of the month and on every Monday.
+//	...
+func Union(scheds ...Scheduler) *Composite {
+	return newComposite(union, scheds)
+}
+
+// Intersect returns a scheduler which runs whenever all of the given schedulers run at the same time.
+// Anchored schedulers are to be used, as the runs of an unanchored Interval depend on the time they are looked from.
+//
+// eg:
+//	...
+//	c := schedule.Intersect(fridays, the13th)   // will run on every Friday the 13th.
+//	...
+func Intersect(scheds ...Scheduler) *Composite {
+	return newComposite(intersect, scheds)
+}
+
+// Except returns a scheduler which runs whenever the base scheduler runs and none of the excluded schedulers run.
+//
+// eg:
+//	...
+//	c := schedule.Except(weekdays, holidays)   // will run on weekdays which are not holidays.
+//	...
+func Except(base Scheduler, excluded ...Scheduler) *Composite {
+	return newComposite(except, append([]Scheduler{base}, excluded...))
+}
+
+// newComposite returns a Composite scheduler applying the set operation to the given schedulers.
+func newComposite(op setOp, scheds []Scheduler) *Composite {
+	c := &Composite{
+		schedule: newSched(true, setCtx(nil), systemClock),
+		op:       op,
+		scheds:   scheds,
+	}
+	if len(scheds) == 0 {
+		c.invalid("%s needs at least one scheduler", op)
+	}
+	for _, s := range scheds {
+		if s == nil {
+			c.invalid("%s of a nil scheduler", op)
+		} else if _, ok := s.(Previewer); !ok {
+			c.invalid("%s of %T, which is not a Previewer", op, s)
+		}
+	}
+	return c
+}
+
+// SetClock sets the clock of the scheduler, which is used to tell the current time.
+// The clocks of the combined schedulers are left untouched, as only their NextAfter is used.
+//
+// eg:
+//	...
+//	c.SetClock(schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func (c Composite) SetClock(clock Clock) Composite {
+	c.setClock(clock)
+	return c
+}
+
+// Next finds the next run of the scheduler from the current time of its clock and prepares it to run.
+func (c Composite) Next() (Scheduler, error) {
+	now := c.timeSource().Now()
+	next, err := c.NextAfter(now)
+	if err != nil {
+		return nil, err
+	}
+	c.timer = next
+	c.interval = next.Sub(now)
+	return &c, nil
+}
+
+// NextAfter returns the first time after t at which the scheduler runs.
+//
+// eg:
+//	...
+//	next, err := c.NextAfter(time.Now())   // will return the time the scheduler would run at.
+//	...
+func (c Composite) NextAfter(t time.Time) (time.Time, error) {
+	if c.dur.err != nil {
+		return time.Time{}, c.dur.err
+	}
+	switch c.op {
+	case intersect:
+		return c.nextCommon(t)
+	case except:
+		return c.nextExcept(t)
+	}
+	return c.nextAny(t)
+}
+
+// nextAny returns the earliest run after t of the schedulers, leaving out the ones which have no run after t.
+// The error of the first scheduler is returned when none of them has a run after t.
+func (c *Composite) nextAny(t time.Time) (time.Time, error) {
+	var next time.Time
+	var first error
+	for _, s := range c.scheds {
+		at, err := nextAfter(s, t)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	if next.IsZero() {
+		return next, first
+	}
+	return next, nil
+}
+
+// nextCommon returns the first run after t shared by all the schedulers.
+func (c *Composite) nextCommon(t time.Time) (time.Time, error) {
+	from := t
+	for attemptsRem := maxCandidates; attemptsRem > 0; attemptsRem-- {
+
+		// the latest of the next runs is the earliest time all of the schedulers may run at.
+		var latest time.Time
+		same := true
+		for i, s := range c.scheds {
+			at, err := nextAfter(s, from)
+			if err != nil {
+				return time.Time{}, err
+			}
+			if i > 0 && !at.Equal(latest) {
+				same = false
+			}
+			if at.After(latest) {
+				latest = at
+			}
+		}
+		if same {
+			return latest, nil
+		}
+		from = latest.Add(-time.Nanosecond)
+	}
+	return time.Time{}, fmt.Errorf("%w: no common run found within %d candidates after %s", ErrNoMatch, maxCandidates, t.Format(time.RFC3339))
+}
+
+// nextExcept returns the first run after t of the base scheduler which none of the excluded schedulers run at.
+func (c *Composite) nextExcept(t time.Time) (time.Time, error) {
+	base, excluded := c.scheds[0], c.scheds[1:]
+	from := t
+	for attemptsRem := maxCandidates; attemptsRem > 0; attemptsRem-- {
+		at, err := nextAfter(base, from)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !runsAt(excluded, at) {
+			return at, nil
+		}
+		from = at
+	}
+	return time.Time{}, fmt.Errorf("%w: every run within %d candidates after %s is excluded", ErrNoMatch, maxCandidates, t.Format(time.RFC3339))
+}
+
+// runsAt reports whether any of the schedulers runs at t.
+func runsAt(scheds []Scheduler, t time.Time) bool {
+	for _, s := range scheds {
+		if at, err := nextAfter(s, t.Add(-time.Nanosecond)); err == nil && at.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the set operation applied to the schedulers and the time until the next run of the scheduler.
+// eg:
+//	...
+//	c := schedule.Union(a, b)
+//	fmt.Println(c.String()) // will print: union(<a>; <b>) -> next execution in 1h0m0s
+//	...
+func (c *Composite) String() string {
+	parts := make([]string, len(c.scheds))
+	for i, s := range c.scheds {
+		if s == nil {
+			parts[i] = "<nil>"
+			continue
+		}
+		parts[i] = s.String()
+	}
+	return fmt.Sprintf("%s(%s) -> next execution in %s", c.op, strings.Join(parts, "; "), c.interval)
+}
@@ -0,0 +1,164 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComposite_NextAfter(t *testing.T) {
+	midnight := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	every := func(d time.Duration) Scheduler {
+		i := ByFreq(true).AddNsec(int(d)).SetAnchor(midnight)
+		return &i
+	}
+	firstOfMonth := ByTimestamp(true).SetDates(1).SetHours(0).SetMinutes(0).SetSeconds(0)
+	mondays := ByTimestamp(true).SetDays(Monday).SetHours(0).SetMinutes(0).SetSeconds(0)
+	fridays := ByTimestamp(true).SetDays(Friday).SetHours(0).SetMinutes(0).SetSeconds(0)
+	the13th := ByTimestamp(true).SetDates(13).SetHours(0).SetMinutes(0).SetSeconds(0)
+	night := ByTimestamp(true).SetHours(2)
+	in2019 := ByTimestamp(true).SetYear(2019).SetMinutes(0).SetSeconds(0)
+	day := func(m time.Month, d int) time.Time { return time.Date(2020, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		c       *Composite
+		after   time.Time
+		n       int
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name:  "union of dates and days",
+			c:     Union(&firstOfMonth, &mondays),
+			after: day(time.January, 25),
+			n:     4,
+			want:  []time.Time{day(time.January, 27), day(time.February, 1), day(time.February, 3), day(time.February, 10)},
+		}, {
+			name:  "union runs once on shared times",
+			c:     Union(every(15*time.Minute), every(10*time.Minute)),
+			after: midnight,
+			n:     5,
+			want: []time.Time{
+				midnight.Add(10 * time.Minute), midnight.Add(15 * time.Minute), midnight.Add(20 * time.Minute),
+				midnight.Add(30 * time.Minute), midnight.Add(40 * time.Minute),
+			},
+		}, {
+			name:  "union leaves out exhausted schedulers",
+			c:     Union(&in2019, every(time.Hour)),
+			after: midnight,
+			n:     2,
+			want:  []time.Time{midnight.Add(time.Hour), midnight.Add(2 * time.Hour)},
+		}, {
+			name:    "union of exhausted schedulers",
+			c:       Union(&in2019),
+			after:   midnight,
+			n:       1,
+			wantErr: true,
+		}, {
+			name:  "intersection of days and dates",
+			c:     Intersect(&fridays, &the13th),
+			after: midnight,
+			n:     3,
+			want:  []time.Time{day(time.March, 13), day(time.November, 13), time.Date(2021, time.August, 13, 0, 0, 0, 0, time.UTC)},
+		}, {
+			name:  "intersection of intervals",
+			c:     Intersect(every(15*time.Minute), every(10*time.Minute)),
+			after: midnight,
+			n:     2,
+			want:  []time.Time{midnight.Add(30 * time.Minute), midnight.Add(time.Hour)},
+		}, {
+			name:    "intersection without common runs",
+			c:       Intersect(every(2*time.Hour), Except(every(time.Hour), every(2*time.Hour))),
+			after:   midnight,
+			n:       1,
+			wantErr: true,
+		}, {
+			name:  "difference",
+			c:     Except(every(10*time.Minute), &night),
+			after: midnight.Add(time.Hour + 45*time.Minute),
+			n:     3,
+			want:  []time.Time{midnight.Add(time.Hour + 50*time.Minute), midnight.Add(3 * time.Hour), midnight.Add(3*time.Hour + 10*time.Minute)},
+		}, {
+			name:  "nested",
+			c:     Except(Union(&firstOfMonth, &mondays), &fridays),
+			after: day(time.April, 20), // the 1st of May is a Friday.
+			n:     3,
+			want:  []time.Time{day(time.April, 27), day(time.May, 4), day(time.May, 11)},
+		}, {
+			name:    "no schedulers",
+			c:       Union(),
+			after:   midnight,
+			n:       1,
+			wantErr: true,
+		}, {
+			name:    "nil scheduler",
+			c:       Intersect(every(time.Hour), nil),
+			after:   midnight,
+			n:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextN(tt.c, tt.after, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Composite.NextAfter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Composite.NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposite_Next(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.January, 1, 0, 5, 0, 0, time.UTC))
+	quarters := ByTimestamp(true).SetMinutes(0, 15, 30, 45).SetSeconds(0)
+	tens := ByTimestamp(true).SetMinutes(0, 10, 20, 30, 40, 50).SetSeconds(0)
+
+	s, err := Union(&quarters, &tens).SetClock(c).Next()
+	if err != nil {
+		t.Fatalf("Composite.Next() error = %v", err)
+	}
+	if got, want := s.(*Composite).timer, time.Date(2020, time.January, 1, 0, 10, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Composite.Next() = %v, want %v", got, want)
+	}
+	if got, want := s.(*Composite).interval, 5*time.Minute; got != want {
+		t.Errorf("Composite.Next() interval = %v, want %v", got, want)
+	}
+}
+
+func TestComposite_String(t *testing.T) {
+	hourly := ByFreq(true).AddHour(1)
+	daily := ByFreq(true).AddDay(1)
+	tests := []struct {
+		name string
+		c    *Composite
+		want string
+	}{
+		{
+			name: "union",
+			c:    Union(&hourly, &daily),
+			want: "union(0yrs 0months 0weeks 0days 1hrs 0mins 0secs 0nsecs -> next execution in 0s; " +
+				"0yrs 0months 0weeks 1days 0hrs 0mins 0secs 0nsecs -> next execution in 0s) -> next execution in 0s",
+		}, {
+			name: "nested",
+			c:    Except(Intersect(&hourly), &daily),
+			want: "except(intersect(0yrs 0months 0weeks 0days 1hrs 0mins 0secs 0nsecs -> next execution in 0s) -> next execution in 0s; " +
+				"0yrs 0months 0weeks 1days 0hrs 0mins 0secs 0nsecs -> next execution in 0s) -> next execution in 0s",
+		}, {
+			name: "nil scheduler",
+			c:    Union(nil),
+			want: "union(<nil>) -> next execution in 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("Composite.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListedTimes is the number of times of the day a Timer running at fixed times is described with,
+// a Timer running more often being described by how often it runs.
+const maxListedTimes = 6
+
+// Describe returns how often the scheduler runs in plain English, for people reading the schedule rather than the code.
+// The location of the scheduler is named last.
+//
+// eg:
+//	...
+//	t := schedule.ByTimestamp(true).SetDays(schedule.Monday).SetHours(3).SetMinutes(0).SetSeconds(0)
+//	fmt.Println(t.Describe())   // will print: every Monday at 03:00 UTC
+//	...
+func (t Timer) Describe() string {
+	d := t.resolved()
+	hours, minutes, seconds := d.values(hour), d.values(minute), d.values(second)
+
+	var s string
+	if times := d.times(hours, minutes, seconds); times != nil {
+		s = d.describeDays(false) + " at " + joinAnd(times)
+	} else {
+		s = describeTimes(hours, minutes, seconds)
+		if days := d.describeDays(true); days != "" {
+			s += " " + days
+		}
+	}
+	s += " " + d.location.String()
+	if !t.repeat {
+		s += ", only once"
+	}
+	return t.describeExtras(s)
+}
+
+// Describe returns how often the scheduler runs in plain English, for people reading the schedule rather than the code.
+// The first run of an anchored scheduler is given in the location of the scheduler.
+//
+// eg:
+//	...
+//	i := schedule.ByFreq(true).AddHour(2).AddMinute(30).SetAnchor(start)
+//	fmt.Println(i.Describe())   // will print: every 2 hours 30 minutes, starting at 2020-01-01 09:00 UTC
+//	...
+func (i Interval) Describe() string {
+	units := []struct {
+		val  int
+		name string
+	}{
+		{i.dur.Year, "year"}, {i.dur.Month, "month"}, {i.dur.Week, "week"}, {i.dur.Day, "day"},
+		{i.dur.Hour, "hour"}, {i.dur.Minute, "minute"}, {i.dur.Second, "second"},
+	}
+	var parts []string
+	for _, u := range units {
+		if u.val != 0 {
+			parts = append(parts, plural(u.val, u.name))
+		}
+	}
+	if ns := i.dur.Nsec; ns != 0 {
+		switch {
+		case ns%int(time.Millisecond) == 0:
+			parts = append(parts, plural(ns/int(time.Millisecond), "millisecond"))
+		case ns%int(time.Microsecond) == 0:
+			parts = append(parts, plural(ns/int(time.Microsecond), "microsecond"))
+		default:
+			parts = append(parts, plural(ns, "nanosecond"))
+		}
+	}
+
+	var s string
+	switch {
+	case !i.repeat:
+		s = "once after " + strings.Join(parts, " ")
+	case len(parts) == 0:
+		s = "continuously"
+	case len(parts) == 1 && strings.HasPrefix(parts[0], "1 "):
+		// every hour rather than every 1 hour
+		s = "every " + parts[0][2:]
+	default:
+		s = "every " + strings.Join(parts, " ")
+	}
+	if !i.anchor.IsZero() {
+		at := i.anchor.In(i.dur.location)
+		layout := "2006-01-02 15:04 MST"
+		if at.Second() != 0 || at.Nanosecond() != 0 {
+			layout = "2006-01-02 15:04:05 MST"
+		}
+		s += ", starting at " + at.Format(layout)
+	} else if i.mode == FixedRate {
+		s += " at a fixed rate"
+	}
+	switch i.missed {
+	case MissedFireOnce:
+		s += ", running once right away for the missed runs"
+	case MissedFireAll:
+		s += ", running each missed run right away"
+	}
+	return i.describeExtras(s)
+}
+
+// describeExtras adds the runs skipped, the bounds, the calendar and the jitter of the scheduler to the description.
+func (s *schedule) describeExtras(desc string) string {
+	if n := s.nth(); n > 1 {
+		desc += ", only every " + ordinal(n) + " run"
+		if !s.everyFrom.IsZero() {
+			desc += " counting from " + s.everyFrom.Format("2006-01-02 15:04 MST")
+		}
+	}
+	if !s.start.IsZero() && !s.start.Equal(s.anchor) {
+		desc += ", from " + s.start.Format("2006-01-02 15:04 MST")
+	}
+	if !s.end.IsZero() {
+		desc += ", until " + s.end.Format("2006-01-02 15:04 MST")
+	}
+	switch {
+	case s.maxRuns == 1:
+		desc += ", at most once"
+	case s.maxRuns > 1:
+		desc += ", at most " + plural(s.maxRuns, "time")
+	}
+	if s.calendar != nil {
+		desc += ", except on the dates excluded by its calendar"
+	}
+	if s.jitter.set() {
+		desc += ", give or take " + strings.TrimPrefix(s.jitter.String(), "±")
+	}
+	return desc
+}
+
+// resolved returns a copy of the duration of the Timer with its hashed time units resolved,
+// leaving the hashed time units unset when they cannot be resolved. The time units set to all their values are
+// left unset as well, so they read "every", eg: the 12 months of a cron expression.
+func (t *Timer) resolved() *duration {
+	d := *t.dur
+	_ = d.resolveHashes()
+	sets := make(map[timeUnit][]int, len(d.sets))
+	for units, vals := range d.sets {
+		// every date, or every weekday, still lets the other one alone match when either of them is enough.
+		lim := limits[units]
+		if len(vals) == lim[1]-lim[0]+1 && !(d.dayOrDate && (units == date || units == day)) {
+			continue
+		}
+		sets[units] = vals
+	}
+	d.sets = sets
+	return &d
+}
+
+// times returns the times of the day the schedule runs at, or nil when it runs at more than maxListedTimes of them.
+func (d *duration) times(hours, minutes, seconds []int) []string {
+	if hours == nil || minutes == nil || seconds == nil || len(hours)*len(minutes)*len(seconds) > maxListedTimes {
+		return nil
+	}
+	withSeconds := len(seconds) > 1 || seconds[0] != 0 || d.nsec() != 0
+	var times []string
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, sec := range seconds {
+				times = append(times, clockTime(h, m, sec, d.nsec(), withSeconds))
+			}
+		}
+	}
+	return times
+}
+
+// clockTime formats the time of the day, eg: 09:30 or 09:30:15.
+func clockTime(h, m, s, ns int, withSeconds bool) string {
+	if !withSeconds {
+		return fmt.Sprintf("%02d:%02d", h, m)
+	}
+	t := fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	if ns != 0 {
+		t += strings.TrimRight(fmt.Sprintf(".%09d", ns), "0")
+	}
+	return t
+}
+
+// describeTimes describes how often within the day a schedule runs, which runs at more than maxListedTimes of the day.
+// The finest time unit not set to a single value tells how often it runs, the finer ones when within it and
+// the coarser ones during which part of the day.
+func describeTimes(hours, minutes, seconds []int) string {
+	single := func(vals []int) bool { return len(vals) == 1 }
+	switch {
+	case !single(seconds):
+		s := describeUnit(seconds, second, "minute")
+		if minutes != nil {
+			s += " during " + describeDuring(minutes)
+		}
+		return s + describeHours(hours)
+
+	case !single(minutes):
+		s := describeUnit(minutes, minute, "hour")
+		if seconds[0] != 0 {
+			s += fmt.Sprintf(" at %s past the minute", plural(seconds[0], "second"))
+		}
+		return s + describeHours(hours)
+	}
+
+	// the hours are neither a single value nor few enough to be listed, the minute and second are set.
+	m, sec := minutes[0], seconds[0]
+	if hours == nil || isStep(hours, limits[hour][1]) {
+		s := describeUnit(hours, hour, "day")
+		if m != 0 || sec != 0 {
+			past := plural(m, "minute")
+			if sec != 0 {
+				past += " " + plural(sec, "second")
+			}
+			s = "at " + past + " past " + s
+		}
+		return s
+	}
+	if from, to, ok := isRange(hours); ok {
+		return fmt.Sprintf("every hour from %s to %s", clockTime(from, m, sec, 0, sec != 0), clockTime(to, m, sec, 0, sec != 0))
+	}
+	times := make([]string, len(hours))
+	for i, h := range hours {
+		times[i] = clockTime(h, m, sec, 0, sec != 0)
+	}
+	return "at " + joinAnd(times)
+}
+
+// describeUnit describes how often within its parent time unit a schedule runs at the given values of the time unit.
+// eg: every minute, every 15 minutes, every minute from 10 to 20 past the hour, at minutes 5 and 35 past the hour.
+func describeUnit(vals []int, units timeUnit, parent string) string {
+	name := units.String()
+	switch {
+	case vals == nil:
+		return "every " + name
+	case isStep(vals, limits[units][1]):
+		return "every " + plural(vals[1]-vals[0], name)
+	}
+	if from, to, ok := isRange(vals); ok {
+		return fmt.Sprintf("every %s from %d to %d past the %s", name, from, to, parent)
+	}
+	return fmt.Sprintf("at %ss %s past the %s", name, joinAnd(itoas(vals)), parent)
+}
+
+// describeDuring describes the minutes of the hour a schedule runs during.
+func describeDuring(vals []int) string {
+	if len(vals) == 1 {
+		return fmt.Sprintf("minute %d", vals[0])
+	}
+	if from, to, ok := isRange(vals); ok {
+		return fmt.Sprintf("minutes %d to %d", from, to)
+	}
+	return "minutes " + joinAnd(itoas(vals))
+}
+
+// describeHours describes the hours of the day a schedule runs during, nothing when it runs every hour.
+func describeHours(hours []int) string {
+	if hours == nil {
+		return ""
+	}
+	if len(hours) == 1 {
+		return fmt.Sprintf(" between %s and %s", clockTime(hours[0], 0, 0, 0, false), clockTime(hours[0], 59, 0, 0, false))
+	}
+	if from, to, ok := isRange(hours); ok {
+		return fmt.Sprintf(" between %s and %s", clockTime(from, 0, 0, 0, false), clockTime(to, 59, 0, 0, false))
+	}
+	return " during hours " + joinAnd(itoas(hours))
+}
+
+// describeDays describes the days a schedule runs on, followed by its years.
+// Within a day the description reads "every Monday" or "the 1st of every month", while for a schedule running
+// several times a day it reads "on Mondays" or "on the 1st of every month", and is empty when it runs every day.
+func (d *duration) describeDays(withinDay bool) string {
+	months := d.values(month)
+	ofMonths := "every month"
+	if months != nil {
+		names := make([]string, len(months))
+		for i, m := range months {
+			names[i] = time.Month(m).String()
+		}
+		ofMonths = joinAnd(names)
+	}
+
+	// the date rules of the schedule
+	var dates []string
+	if vals := d.values(date); vals != nil {
+		ords := make([]string, len(vals))
+		for i, v := range vals {
+			ords[i] = ordinal(v)
+		}
+		dates = append(dates, "the "+joinAnd(ords))
+	}
+	if d.lastDate {
+		dates = append(dates, "the last day")
+	}
+	for _, v := range d.nearestDates {
+		dates = append(dates, "the weekday nearest the "+ordinal(v))
+	}
+
+	// the weekday rules of the schedule, the weekly ones apart from the ones running once a month
+	weekdays := d.values(day)
+	var monthly []string
+	for _, v := range d.lastDays {
+		monthly = append(monthly, "the last "+time.Weekday(v).String())
+	}
+	for _, v := range d.nthDays {
+		monthly = append(monthly, "the "+nthWords[v[1]]+" "+time.Weekday(v[0]).String())
+	}
+
+	var s string
+	switch {
+	case dates != nil && (weekdays != nil || monthly != nil) && !d.dayOrDate:
+		// the dates only run when they fall on one of the weekdays
+		var on []string
+		if weekdays != nil {
+			on = append(on, "a "+weekdayNames(weekdays, false, " or "))
+		}
+		on = append(on, monthly...)
+		s = joinAnd(dates) + " of " + ofMonths + " when it falls on " + strings.Join(on, " or ")
+		if withinDay {
+			s = "on " + s
+		}
+	case dates != nil || weekdays != nil || monthly != nil:
+		var parts []string
+		if dates != nil || monthly != nil {
+			parts = append(parts, joinAnd(append(dates, monthly...))+" of "+ofMonths)
+			if withinDay {
+				parts[0] = "on " + parts[0]
+			}
+		}
+		if weekdays != nil {
+			week := "every " + weekdayNames(weekdays, false, " and ")
+			if withinDay {
+				week = "on " + weekdayNames(weekdays, true, " and ")
+			}
+			if months != nil {
+				week += " in " + ofMonths
+			}
+			parts = append(parts, week)
+		}
+		s = strings.Join(parts, " and ")
+	case months != nil:
+		s = "in " + ofMonths
+		if !withinDay {
+			s = "every day " + s
+		}
+	case !withinDay:
+		s = "every day"
+	}
+
+	if years := d.values(year); years != nil {
+		if s != "" {
+			s += " "
+		}
+		s += "in " + joinAnd(itoas(years))
+	}
+	return s
+}
+
+// nthWords names the occurrences of a weekday within the month.
+var nthWords = map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", 5: "fifth"}
+
+// weekdayNames names the weekdays, calling Monday to Friday weekdays, in the plural for "on Mondays".
+func weekdayNames(vals []int, pl bool, sep string) string {
+	if len(vals) == 5 && vals[0] == int(Monday) && vals[4] == int(Friday) {
+		if pl {
+			return "weekdays"
+		}
+		return "weekday"
+	}
+	names := make([]string, len(vals))
+	for i, v := range vals {
+		names[i] = time.Weekday(v).String()
+		if pl {
+			names[i] += "s"
+		}
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + sep + names[len(names)-1]
+}
+
+// isStep reports whether the values run from 0 to the limit at a fixed step of more than 1, eg: 0,15,30,45.
+func isStep(vals []int, limit int) bool {
+	if len(vals) < 2 || vals[0] != 0 {
+		return false
+	}
+	step := vals[1] - vals[0]
+	if step < 2 {
+		return false
+	}
+	for i := range vals {
+		if vals[i] != i*step {
+			return false
+		}
+	}
+	return vals[len(vals)-1]+step > limit
+}
+
+// isRange reports whether the values are a contiguous range of more than one value, returning its bounds.
+func isRange(vals []int) (from, to int, ok bool) {
+	if len(vals) < 2 {
+		return 0, 0, false
+	}
+	for i := 1; i < len(vals); i++ {
+		if vals[i] != vals[i-1]+1 {
+			return 0, 0, false
+		}
+	}
+	return vals[0], vals[len(vals)-1], true
+}
+
+// plural returns the count followed by the name, in the plural unless the count is 1.
+func plural(n int, name string) string {
+	if n == 1 {
+		return "1 " + name
+	}
+	return strconv.Itoa(n) + " " + name + "s"
+}
+
+// ordinal returns the ordinal of the number, eg: 1st, 2nd, 11th or 23rd.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// itoas formats the numbers.
+func itoas(vals []int) []string {
+	s := make([]string, len(vals))
+	for i, v := range vals {
+		s[i] = strconv.Itoa(v)
+	}
+	return s
+}
+
+// joinAnd joins the items as a list, eg: a, b and c.
+func joinAnd(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
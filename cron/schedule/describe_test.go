@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimer_Describe(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		timer Timer
+		want  string
+	}{
+		{
+			name:  "weekly",
+			timer: ByTimestamp(true).SetDays(Monday).SetHours(3).SetMinutes(0).SetSeconds(0),
+			want:  "every Monday at 03:00 UTC",
+		}, {
+			name:  "daily at two times",
+			timer: ByTimestamp(true).SetHours(9, 17).SetMinutes(30).SetSeconds(0).SetLocation(berlin),
+			want:  "every day at 09:30 and 17:30 Europe/Berlin",
+		}, {
+			name:  "legacy setters with seconds",
+			timer: ByTimestamp(true).SetHour(6).SetMinute(5).SetSecond(30),
+			want:  "every day at 06:05:30 UTC",
+		}, {
+			name:  "weekdays",
+			timer: ByTimestamp(true).SetDayRange(Monday, Friday).SetHours(9).SetMinutes(0).SetSeconds(0),
+			want:  "every weekday at 09:00 UTC",
+		}, {
+			name:  "every 15 minutes during office hours",
+			timer: ByTimestamp(true).SetDayRange(Monday, Friday).SetHourRange(9, 17).SetMinuteStep(15).SetSeconds(0),
+			want:  "every 15 minutes between 09:00 and 17:59 on weekdays UTC",
+		}, {
+			name:  "every second",
+			timer: ByTimestamp(true).SetYear(2030),
+			want:  "every second in 2030 UTC",
+		}, {
+			name:  "every second of an hour",
+			timer: ByTimestamp(true).SetHours(9),
+			want:  "every second between 09:00 and 09:59 UTC",
+		}, {
+			name:  "past every hour",
+			timer: ByTimestamp(true).SetMinutes(30).SetSeconds(0),
+			want:  "at 30 minutes past every hour UTC",
+		}, {
+			name:  "every 2 hours",
+			timer: ByTimestamp(true).SetHourStep(2).SetMinutes(0).SetSeconds(0),
+			want:  "every 2 hours UTC",
+		}, {
+			name:  "every hour of a range",
+			timer: ByTimestamp(true).SetHourRange(8, 18).SetMinutes(0).SetSeconds(0),
+			want:  "every hour from 08:00 to 18:00 UTC",
+		}, {
+			name:  "minutes past the hour",
+			timer: ByTimestamp(true).SetMinutes(5, 20, 35).SetSeconds(10),
+			want:  "at minutes 5, 20 and 35 past the hour at 10 seconds past the minute UTC",
+		}, {
+			name:  "dates of some months",
+			timer: ByTimestamp(true).SetMonths(January, July).SetDates(1, 15).SetHours(0).SetMinutes(0).SetSeconds(0),
+			want:  "the 1st and 15th of January and July at 00:00 UTC",
+		}, {
+			name:  "last and nearest dates",
+			timer: ByTimestamp(true).SetLastDate().SetNearestWeekday(15).SetHours(12).SetMinutes(0).SetSeconds(0),
+			want:  "the last day and the weekday nearest the 15th of every month at 12:00 UTC",
+		}, {
+			name:  "nth and last weekday",
+			timer: ByTimestamp(true).SetNthDay(Tuesday, 2).SetLastDay(Friday).SetHours(18).SetMinutes(0).SetSeconds(0),
+			want:  "the last Friday and the second Tuesday of every month at 18:00 UTC",
+		}, {
+			name:  "date falling on a weekday",
+			timer: ByTimestamp(true).SetDates(13).SetDays(Friday).SetHours(0).SetMinutes(0).SetSeconds(0),
+			want:  "the 13th of every month when it falls on a Friday at 00:00 UTC",
+		}, {
+			name:  "date or weekday",
+			timer: ByTimestamp(true).SetDates(1).SetDays(Saturday, Sunday).SetDayOrDate(true).SetHours(8).SetMinutes(0).SetSeconds(0),
+			want:  "the 1st of every month and every Sunday and Saturday at 08:00 UTC",
+		}, {
+			name:  "weekly in some months",
+			timer: ByTimestamp(true).SetMonthRange(June, August).SetDays(Wednesday).SetHours(7).SetMinutes(0).SetSeconds(0),
+			want:  "every Wednesday in June, July and August at 07:00 UTC",
+		}, {
+			name:  "every day of a month",
+			timer: ByTimestamp(true).SetMonths(December).SetHours(7).SetMinutes(0).SetSeconds(0),
+			want:  "every day in December at 07:00 UTC",
+		}, {
+			name:  "every month and every hour",
+			timer: ByTimestamp(true).SetMonthRange(January, December).SetHourRange(0, 23).SetDays(Monday).SetMinutes(0).SetSeconds(0),
+			want:  "every hour on Mondays UTC",
+		}, {
+			name:  "hashed minute",
+			timer: ByTimestamp(true).SetHashKey("backup").SetMinuteHash(0, 59).SetHours(2).SetSeconds(0),
+			want:  fmt.Sprintf("every day at 02:%02d UTC", hashOf("backup", minute)%60),
+		}, {
+			name:  "every nth run",
+			timer: ByTimestamp(true).SetDays(Friday).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(3, time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)),
+			want:  "every Friday at 09:00 UTC, only every 3rd run counting from 2020-01-03 00:00 UTC",
+		}, {
+			name:  "once with jitter",
+			timer: ByTimestamp(false).SetHours(4).SetMinutes(0).SetSeconds(0).SetJitter(5 * time.Second),
+			want:  "every day at 04:00 UTC, only once, give or take 5s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.timer.Describe(); got != tt.want {
+				t.Errorf("Timer.Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterval_Describe(t *testing.T) {
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		interval Interval
+		want     string
+	}{
+		{
+			name:     "single unit",
+			interval: ByFreq(true).AddHour(1),
+			want:     "every hour",
+		}, {
+			name:     "several units",
+			interval: ByFreq(true).AddHour(2).AddMinute(30),
+			want:     "every 2 hours 30 minutes",
+		}, {
+			name:     "anchored",
+			interval: ByFreq(true).AddHour(2).AddMinute(30).SetAnchor(start),
+			want:     "every 2 hours 30 minutes, starting at 2020-01-01 09:00 UTC",
+		}, {
+			name:     "fixed rate",
+			interval: ByFreq(true).AddMinute(5).SetMode(FixedRate).SetMissedPolicy(MissedFireOnce),
+			want:     "every 5 minutes at a fixed rate, running once right away for the missed runs",
+		}, {
+			name:     "weeks and days",
+			interval: ByFreq(true).AddWeek(2).AddDay(1),
+			want:     "every 2 weeks 1 day",
+		}, {
+			name:     "milliseconds",
+			interval: ByFreq(true).AddSecond(1).AddNsec(500000000),
+			want:     "every 1 second 500 milliseconds",
+		}, {
+			name:     "once",
+			interval: ByFreq(false).AddMinute(10),
+			want:     "once after 10 minutes",
+		}, {
+			name:     "bounded",
+			interval: ByFreq(true).AddHour(2).StartAt(start).EndAt(start.AddDate(0, 0, 7)).MaxRuns(10),
+			want:     "every 2 hours, starting at 2020-01-01 09:00 UTC, until 2020-01-08 09:00 UTC, at most 10 times",
+		}, {
+			name:     "calendar",
+			interval: ByFreq(true).AddDay(1).SetCalendar(NewCalendar(nil)),
+			want:     "every day, except on the dates excluded by its calendar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.interval.Describe(); got != tt.want {
+				t.Errorf("Interval.Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ordinal(t *testing.T) {
+	tests := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 31: "31st"}
+	for n, want := range tests {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GapPolicy decides how a Timer runs on the wall times skipped by a forward daylight saving transition,
+// eg: 02:30 on the night the clocks of America/New_York go from 02:00 straight to 03:00.
+type GapPolicy int
+
+const (
+	// GapShiftForward runs the scheduler once at the end of the gap, on the first wall time which exists after it.
+	// It is the default policy.
+	GapShiftForward GapPolicy = iota
+	// GapSkip does not run the scheduler on the skipped wall times.
+	GapSkip
+)
+
+// String returns the name of the gap policy.
+func (p GapPolicy) String() string {
+	switch p {
+	case GapShiftForward:
+		return "shift-forward"
+	case GapSkip:
+		return "skip"
+	}
+	return fmt.Sprintf("GapPolicy(%d)", int(p))
+}
+
+// OverlapPolicy decides how a Timer runs on the wall times repeated by a backward daylight saving transition,
+// eg: 01:30 on the night the clocks of America/New_York go from 02:00 back to 01:00.
+type OverlapPolicy int
+
+const (
+	// OverlapFireOnce runs the scheduler on the first occurrence of the repeated wall times only.
+	// It is the default policy.
+	OverlapFireOnce OverlapPolicy = iota
+	// OverlapFireTwice runs the scheduler on both occurrences of the repeated wall times.
+	OverlapFireTwice
+)
+
+// String returns the name of the overlap policy.
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapFireOnce:
+		return "fire-once"
+	case OverlapFireTwice:
+		return "fire-twice"
+	}
+	return fmt.Sprintf("OverlapPolicy(%d)", int(p))
+}
+
+// maxShift is the longest daylight saving shift of the time zones, looked ahead of for repeated wall times.
+const maxShift = 3 * time.Hour
+
+// nextInstant returns the earliest whole second at or after from, whose wall time in the location matches the schedule.
+// The wall times skipped or repeated by daylight saving transitions are resolved with the gap and overlap policies.
+func (d *duration) nextInstant(from time.Time) (time.Time, error) {
+	next, err := d.nextWallInstant(from)
+	if err != nil {
+		return next, err
+	}
+
+	// the wall times before the one of from come again after a backward transition which is right ahead.
+	if d.overlap == OverlapFireTwice {
+		if again, ok := d.repeatedInstant(from); ok && again.Before(next) {
+			next = again
+		}
+	}
+	return next, nil
+}
+
+// nextWallInstant returns the earliest instant at or after from of the first wall time matching the schedule,
+// which is not before the wall time of from.
+func (d *duration) nextWallInstant(from time.Time) (time.Time, error) {
+	start := from
+
+	// every pass moves start past a transition, and update fails once no wall time is left up to maxYear.
+	for {
+		wall, err := wallOf(start.In(d.location)).update(d)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		ts := wall.instants()
+		if len(ts) == 0 {
+			// the wall time is skipped by a forward transition.
+			end := wall.gapEnd()
+			if d.gap == GapShiftForward {
+				return end, nil
+			}
+			start = end
+			continue
+		}
+
+		all := ts
+		if d.overlap == OverlapFireOnce {
+			ts = ts[:1]
+		}
+		for _, t := range ts {
+			if !t.Before(from) {
+				return t, nil
+			}
+		}
+
+		// the first occurrence of the repeated wall time is gone, move on to the end of the repeated wall times.
+		if len(all) < 2 {
+			start = all[0].Add(time.Second)
+			continue
+		}
+		start = transition(all[0], all[1]).Add(all[1].Sub(all[0]))
+	}
+}
+
+// repeatedInstant returns the second occurrence of the first wall time matching the schedule, among the wall times
+// before the one of from which are repeated by a backward transition less than maxShift ahead of from.
+func (d *duration) repeatedInstant(from time.Time) (time.Time, bool) {
+	_, off := from.In(d.location).Zone()
+	_, offAhead := from.Add(maxShift).In(d.location).Zone()
+	if offAhead >= off {
+		return time.Time{}, false
+	}
+
+	// the wall times repeated by the transition start with the one right after it.
+	t := transition(from, from.Add(maxShift).In(d.location))
+	wall, err := wallOf(t).update(d)
+	if err != nil || !wall.utc().Before(wallOf(from.In(d.location)).utc()) {
+		return time.Time{}, false
+	}
+	ts := wall.instants()
+	if len(ts) < 2 {
+		return time.Time{}, false
+	}
+	return ts[1], true
+}
+
+// prevInstant returns the latest whole second at or before to, whose wall time in the location matches the schedule.
+// The wall times skipped or repeated by daylight saving transitions are resolved with the gap and overlap policies.
+func (d *duration) prevInstant(to time.Time) (time.Time, error) {
+	prev, err := d.prevWallInstant(to)
+
+	// the wall times after the one of to already came once before a backward transition which is right behind.
+	if first, ok := d.repeatedBefore(to); ok && (err != nil || first.After(prev)) {
+		return first, nil
+	}
+	return prev, err
+}
+
+// prevWallInstant returns the latest instant at or before to of the last wall time matching the schedule,
+// which is not after the wall time of to.
+func (d *duration) prevWallInstant(to time.Time) (time.Time, error) {
+	end := to
+
+	// every pass moves end before a transition, and rewind fails once no wall time is left down to the year 1.
+	for {
+		wall, err := wallOf(end.In(d.location)).rewind(d)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		ts := wall.instants()
+		if len(ts) == 0 {
+			// the wall time is skipped by a forward transition.
+			start := wall.gapEnd()
+			if d.gap == GapShiftForward {
+				return start, nil
+			}
+			end = start.Add(-time.Second)
+			continue
+		}
+
+		if d.overlap == OverlapFireOnce {
+			ts = ts[:1]
+		}
+		for i := len(ts) - 1; i >= 0; i-- {
+			if !ts[i].After(to) {
+				return ts[i], nil
+			}
+		}
+		end = ts[0].Add(-time.Second)
+	}
+}
+
+// repeatedBefore returns the first occurrence of the last wall time matching the schedule, among the wall times
+// after the one of to which were repeated by a backward transition less than maxShift before to.
+func (d *duration) repeatedBefore(to time.Time) (time.Time, bool) {
+	_, off := to.In(d.location).Zone()
+	_, offBehind := to.Add(-maxShift).In(d.location).Zone()
+	if offBehind <= off {
+		return time.Time{}, false
+	}
+
+	// the wall times repeated by the transition end with the one right before it.
+	t := transition(to.Add(-maxShift), to.In(d.location))
+	wall, err := wallOf(t.Add(-time.Second)).rewind(d)
+	if err != nil || !wall.utc().After(wallOf(to.In(d.location)).utc()) {
+		return time.Time{}, false
+	}
+	ts := wall.instants()
+	if len(ts) < 2 {
+		return time.Time{}, false
+	}
+	return ts[0], true
+}
+
+// wallOf returns the wall time of t, to the second, in the location of t.
+func wallOf(t time.Time) duration {
+	return duration{
+		Year:     t.Year(),
+		Month:    int(t.Month()),
+		date:     t.Day(),
+		Hour:     t.Hour(),
+		Minute:   t.Minute(),
+		Second:   t.Second(),
+		location: t.Location(),
+	}
+}
+
+// utc returns the wall time as if it was read in UTC.
+func (d duration) utc() time.Time {
+	return time.Date(d.Year, time.Month(d.Month), d.date, d.Hour, d.Minute, d.Second, 0, time.UTC)
+}
+
+// offsets returns the offsets of the location in effect around the wall time, in seconds east of UTC.
+func (d duration) offsets() (before, after int) {
+	u := d.utc()
+	_, before = u.Add(-24 * time.Hour).In(d.location).Zone()
+	_, after = u.Add(24 * time.Hour).In(d.location).Zone()
+	return
+}
+
+// instants returns the instants at which the wall clock of the location shows the wall time, in increasing order.
+// There are none for a wall time skipped by a forward transition, and two for a wall time repeated by a backward transition.
+func (d duration) instants() (ts []time.Time) {
+	u := d.utc()
+	before, after := d.offsets()
+	for _, off := range []int{before, after} {
+		t := u.Add(-time.Duration(off) * time.Second).In(d.location)
+		if _, o := t.Zone(); o != off || (len(ts) > 0 && ts[0].Equal(t)) {
+			continue
+		}
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Before(ts[j]) })
+	return ts
+}
+
+// gapEnd returns the instant at which the forward transition skipping the wall time occurs.
+func (d duration) gapEnd() time.Time {
+	u := d.utc()
+	before, after := d.offsets()
+	return transition(u.Add(-time.Duration(after)*time.Second), u.Add(-time.Duration(before)*time.Second).In(d.location))
+}
+
+// transition returns the first whole second after lo, up to hi, from which the offset of the location of hi is the one at hi.
+func transition(lo, hi time.Time) time.Time {
+	loc := hi.Location()
+	_, want := hi.Zone()
+	l, h := lo.Unix(), hi.Unix()
+	for h-l > 1 {
+		m := l + (h-l)/2
+		if _, off := time.Unix(m, 0).In(loc).Zone(); off == want {
+			h = m
+		} else {
+			l = m
+		}
+	}
+	return time.Unix(h, 0).In(loc)
+}
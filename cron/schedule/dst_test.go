@@ -0,0 +1,308 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func Test_duration_findNextDate_dst(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+
+	// in 2021, the clocks of New York go from 02:00 EST to 03:00 EDT on March 14th and from 02:00 EDT to 01:00 EST on November 7th,
+	// the clocks of London go from 01:00 GMT to 02:00 BST on March 28th and from 02:00 BST to 01:00 GMT on October 31st.
+	at := func(h, m int) func(t Timer) Timer {
+		return func(t Timer) Timer { return t.SetHours(h).SetMinutes(m).SetSeconds(0) }
+	}
+	quarters := func(t Timer) Timer { return t.SetMinuteStep(15).SetSeconds(0) }
+
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		loc     *time.Location
+		gap     GapPolicy
+		overlap OverlapPolicy
+		now     time.Time
+		want    time.Time
+	}{
+		{
+			name:  "new york gap shifts forward",
+			build: at(2, 30),
+			loc:   ny,
+			gap:   GapShiftForward,
+			now:   time.Date(2021, time.March, 13, 3, 0, 0, 0, ny),
+			want:  time.Date(2021, time.March, 14, 3, 0, 0, 0, ny),
+		}, {
+			name:  "new york gap skipped",
+			build: at(2, 30),
+			loc:   ny,
+			gap:   GapSkip,
+			now:   time.Date(2021, time.March, 13, 3, 0, 0, 0, ny),
+			want:  time.Date(2021, time.March, 15, 2, 30, 0, 0, ny),
+		}, {
+			name:  "new york gap shifted once for several wall times",
+			build: quarters,
+			loc:   ny,
+			gap:   GapShiftForward,
+			now:   time.Date(2021, time.March, 14, 1, 45, 0, 0, ny),
+			want:  time.Date(2021, time.March, 14, 3, 0, 0, 0, ny),
+		}, {
+			name:  "new york gap skipped for several wall times",
+			build: func(t Timer) Timer { return t.SetHours(2, 4).SetMinuteStep(15).SetSeconds(0) },
+			loc:   ny,
+			gap:   GapSkip,
+			now:   time.Date(2021, time.March, 14, 1, 45, 0, 0, ny),
+			want:  time.Date(2021, time.March, 14, 4, 0, 0, 0, ny),
+		}, {
+			name:    "new york overlap first occurrence",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			now:     time.Date(2021, time.November, 7, 0, 0, 0, 0, ny),
+			want:    time.Date(2021, time.November, 7, 5, 30, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap fires once",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			now:     time.Date(2021, time.November, 7, 5, 30, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 8, 1, 30, 0, 0, ny),
+		}, {
+			name:    "new york overlap fires twice",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			now:     time.Date(2021, time.November, 7, 5, 30, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 6, 30, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap after second occurrence",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			now:     time.Date(2021, time.November, 7, 6, 30, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 8, 1, 30, 0, 0, ny),
+		}, {
+			name:    "new york overlap repeats earlier wall times",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			now:     time.Date(2021, time.November, 7, 5, 50, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 6, 0, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap does not repeat earlier wall times",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			now:     time.Date(2021, time.November, 7, 5, 50, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 7, 0, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap started within second occurrence",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			now:     time.Date(2021, time.November, 7, 6, 20, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 7, 0, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap within second occurrence",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			now:     time.Date(2021, time.November, 7, 6, 20, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 6, 30, 0, 0, time.UTC),
+		}, {
+			name:  "london gap shifts forward",
+			build: func(t Timer) Timer { return t.SetMinutes(30).SetSeconds(0) },
+			loc:   london,
+			gap:   GapShiftForward,
+			now:   time.Date(2021, time.March, 28, 0, 45, 0, 0, london),
+			want:  time.Date(2021, time.March, 28, 1, 0, 0, 0, time.UTC),
+		}, {
+			name:  "london gap skipped",
+			build: func(t Timer) Timer { return t.SetMinutes(30).SetSeconds(0) },
+			loc:   london,
+			gap:   GapSkip,
+			now:   time.Date(2021, time.March, 28, 0, 45, 0, 0, london),
+			want:  time.Date(2021, time.March, 28, 1, 30, 0, 0, time.UTC),
+		}, {
+			name:    "london overlap fires twice",
+			build:   at(1, 15),
+			loc:     london,
+			overlap: OverlapFireTwice,
+			now:     time.Date(2021, time.October, 31, 0, 15, 0, 0, time.UTC),
+			want:    time.Date(2021, time.October, 31, 1, 15, 0, 0, time.UTC),
+		}, {
+			name:    "london overlap fires once",
+			build:   at(1, 15),
+			loc:     london,
+			overlap: OverlapFireOnce,
+			now:     time.Date(2021, time.October, 31, 0, 15, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 1, 1, 15, 0, 0, london),
+		}, {
+			name:  "london summer time",
+			build: at(9, 0),
+			loc:   london,
+			now:   time.Date(2021, time.June, 1, 9, 0, 0, 0, time.UTC),
+			want:  time.Date(2021, time.June, 2, 8, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk)).SetLocation(tt.loc).SetGapPolicy(tt.gap).SetOverlapPolicy(tt.overlap)
+			if err := tr.dur.validate(); err != nil {
+				t.Fatalf("duration.validate() error = %v", err)
+			}
+			got, err := tr.dur.findNextDate(tt.now)
+			if err != nil {
+				t.Errorf("duration.findNextDate() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("duration.findNextDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_duration_prevDate_dst(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+
+	at := func(h, m int) func(t Timer) Timer {
+		return func(t Timer) Timer { return t.SetHours(h).SetMinutes(m).SetSeconds(0) }
+	}
+	quarters := func(t Timer) Timer { return t.SetMinuteStep(15).SetSeconds(0) }
+
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		loc     *time.Location
+		gap     GapPolicy
+		overlap OverlapPolicy
+		before  time.Time
+		want    time.Time
+	}{
+		{
+			name:   "new york gap shifted forward",
+			build:  at(2, 30),
+			loc:    ny,
+			gap:    GapShiftForward,
+			before: time.Date(2021, time.March, 14, 12, 0, 0, 0, ny),
+			want:   time.Date(2021, time.March, 14, 3, 0, 0, 0, ny),
+		}, {
+			name:   "new york gap skipped",
+			build:  at(2, 30),
+			loc:    ny,
+			gap:    GapSkip,
+			before: time.Date(2021, time.March, 14, 12, 0, 0, 0, ny),
+			want:   time.Date(2021, time.March, 13, 2, 30, 0, 0, ny),
+		}, {
+			name:    "new york overlap fired once",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			before:  time.Date(2021, time.November, 7, 7, 0, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 5, 30, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap fired twice",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			before:  time.Date(2021, time.November, 7, 7, 0, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 6, 30, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap between occurrences",
+			build:   at(1, 30),
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			before:  time.Date(2021, time.November, 7, 6, 0, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 5, 30, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap repeated later wall times fired once",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireOnce,
+			before:  time.Date(2021, time.November, 7, 6, 20, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 5, 45, 0, 0, time.UTC),
+		}, {
+			name:    "new york overlap repeated later wall times fired twice",
+			build:   quarters,
+			loc:     ny,
+			overlap: OverlapFireTwice,
+			before:  time.Date(2021, time.November, 7, 6, 20, 0, 0, time.UTC),
+			want:    time.Date(2021, time.November, 7, 6, 15, 0, 0, time.UTC),
+		}, {
+			name:   "london gap shifted forward",
+			build:  func(t Timer) Timer { return t.SetMinutes(30).SetSeconds(0) },
+			loc:    london,
+			gap:    GapShiftForward,
+			before: time.Date(2021, time.March, 28, 1, 15, 0, 0, time.UTC),
+			want:   time.Date(2021, time.March, 28, 1, 0, 0, 0, time.UTC),
+		}, {
+			name:    "london overlap fired twice",
+			build:   at(1, 15),
+			loc:     london,
+			overlap: OverlapFireTwice,
+			before:  time.Date(2021, time.October, 31, 12, 0, 0, 0, time.UTC),
+			want:    time.Date(2021, time.October, 31, 1, 15, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk)).SetLocation(tt.loc).SetGapPolicy(tt.gap).SetOverlapPolicy(tt.overlap)
+			got, err := tr.dur.prevDate(tt.before)
+			if err != nil {
+				t.Errorf("duration.prevDate() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("duration.prevDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_transition(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		lo   time.Time
+		hi   time.Time
+		want time.Time
+	}{
+		{
+			name: "forward",
+			lo:   time.Date(2021, time.March, 14, 0, 0, 0, 0, time.UTC),
+			hi:   time.Date(2021, time.March, 15, 0, 0, 0, 0, time.UTC).In(ny),
+			want: time.Date(2021, time.March, 14, 7, 0, 0, 0, time.UTC),
+		}, {
+			name: "backward",
+			lo:   time.Date(2021, time.November, 7, 0, 0, 0, 0, time.UTC),
+			hi:   time.Date(2021, time.November, 8, 0, 0, 0, 0, time.UTC).In(ny),
+			want: time.Date(2021, time.November, 7, 6, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transition(tt.lo, tt.hi); !got.Equal(tt.want) {
+				t.Errorf("transition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInPast is returned when the next run of a scheduler would not be after the time it is scheduled from,
+	// eg: an Interval of zero or negative duration.
+	ErrInPast = errors.New("time is in the past")
+	// ErrNoMatch is returned when no run of a scheduler matches its settings, either way in time,
+	// eg: a Timer set to run on February 30th.
+	ErrNoMatch = errors.New("no matching run")
+	// ErrInvalid is returned when a scheduler is given a setting it cannot run with, eg: a negative jitter.
+	// Every error of the settings wraps it, the FieldError included.
+	ErrInvalid = errors.New("invalid setting")
+)
+
+// FieldError describes a time unit or a setting of a scheduler given a value out of its range.
+// It is reported when scheduling, and can be told apart from the other errors with errors.As, while errors.Is
+// matches it with ErrInvalid.
+//
+// eg:
+//	...
+//	_, err := t.SetMonth(13).Next()
+//	var ferr *schedule.FieldError
+//	if errors.As(err, &ferr) {
+//		...   // ferr.Field is "month", ferr.Value is 13, ferr.Min is 1 and ferr.Max is 12.
+//	}
+//	...
+type FieldError struct {
+	Field string // name of the time unit or setting, eg: "month".
+	Value int    // value given.
+	Min   int    // lowest value allowed.
+	Max   int    // highest value allowed.
+}
+
+// Error returns the string representation of the field error.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s must be between %d and %d, given %d", e.Field, e.Min, e.Max, e.Value)
+}
+
+// Is reports whether the target is ErrInvalid, which every field error is.
+func (e *FieldError) Is(target error) bool {
+	return target == ErrInvalid
+}
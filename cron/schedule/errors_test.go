@@ -0,0 +1,176 @@
+package schedule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFieldError(t *testing.T) {
+	tests := []struct {
+		name  string
+		timer func(t Timer) Timer
+		want  *FieldError
+	}{
+		{
+			name:  "month",
+			timer: func(t Timer) Timer { return t.SetMonth(13) },
+			want:  &FieldError{Field: "month", Value: 13, Min: 1, Max: 12},
+		}, {
+			name:  "date",
+			timer: func(t Timer) Timer { return t.SetDate(32) },
+			want:  &FieldError{Field: "date", Value: 32, Min: 1, Max: 31},
+		}, {
+			name:  "nanosecond",
+			timer: func(t Timer) Timer { return t.SetSecond(1).SetNanosecond(1e9) },
+			want:  &FieldError{Field: "nanosecond", Value: 1e9, Min: 0, Max: 999999999},
+		}, {
+			name:  "values",
+			timer: func(t Timer) Timer { return t.SetMinutes(0, 30, 60) },
+			want:  &FieldError{Field: "minute", Value: 60, Min: 0, Max: 59},
+		}, {
+			name:  "range",
+			timer: func(t Timer) Timer { return t.SetHourRange(22, 24) },
+			want:  &FieldError{Field: "hour", Value: 24, Min: 0, Max: 23},
+		}, {
+			name:  "nth day occurrence",
+			timer: func(t Timer) Timer { return t.SetNthDay(Monday, 6) },
+			want:  &FieldError{Field: "nth day occurrence", Value: 6, Min: 1, Max: 5},
+		}, {
+			name:  "nearest weekday",
+			timer: func(t Timer) Timer { return t.SetNearestWeekday(0) },
+			want:  &FieldError{Field: "nearest weekday date", Value: 0, Min: 1, Max: 31},
+		}, {
+			name:  "day",
+			timer: func(t Timer) Timer { return t.SetDay(9) },
+			want:  &FieldError{Field: "day", Value: 9, Min: 0, Max: 6},
+		}, {
+			name:  "negative date",
+			timer: func(t Timer) Timer { return t.SetDate(-3) },
+			want:  &FieldError{Field: "date", Value: -3, Min: 1, Max: 31},
+		}, {
+			name:  "hashed range",
+			timer: func(t Timer) Timer { return t.SetHashKey("backup").SetMinuteHash(0, 60) },
+			want:  &FieldError{Field: "hashed minute", Value: 60, Min: 0, Max: 59},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.timer(ByTimestamp(true).SetClock(clk)).NextAfter(clk.Now())
+			var got *FieldError
+			if !errors.As(err, &got) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Timer.NextAfter() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldError_Error(t *testing.T) {
+	err := &FieldError{Field: "month", Value: 13, Min: 1, Max: 12}
+	if got, want := err.Error(), "month must be between 1 and 12, given 13"; got != want {
+		t.Errorf("FieldError.Error() = %v, want %v", got, want)
+	}
+}
+
+func Test_sentinelErrors(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{
+			name: "interval in the past",
+			run: func() error {
+				_, err := ByFreq(true).AddMinute(-5).NextAfter(start)
+				return err
+			},
+			want: ErrInPast,
+		}, {
+			name: "empty interval",
+			run: func() error {
+				_, err := ByFreq(true).NextAfter(start)
+				return err
+			},
+			want: ErrInPast,
+		}, {
+			name: "date which never comes",
+			run: func() error {
+				_, err := ByTimestamp(true).SetMonths(February).SetDates(30).NextAfter(start)
+				return err
+			},
+			want: ErrNoMatch,
+		}, {
+			name: "year gone by",
+			run: func() error {
+				_, err := ByTimestamp(true).SetYear(2019).NextAfter(start)
+				return err
+			},
+			want: ErrNoMatch,
+		}, {
+			name: "before the anchor",
+			run: func() error {
+				_, err := ByFreq(true).AddHour(1).SetAnchor(start).Prev(start)
+				return err
+			},
+			want: ErrNoMatch,
+		}, {
+			name: "before the start",
+			run: func() error {
+				_, err := ByTimestamp(true).SetMinutes(0).SetSeconds(0).StartAt(start).Prev(start)
+				return err
+			},
+			want: ErrNoMatch,
+		}, {
+			name: "value out of range",
+			run: func() error {
+				_, err := ByTimestamp(true).SetMonth(13).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		}, {
+			name: "negative jitter",
+			run: func() error {
+				_, err := ByFreq(true).AddHour(1).SetJitter(-time.Second).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		}, {
+			name: "unknown gap policy",
+			run: func() error {
+				_, err := ByTimestamp(true).SetHours(2).SetGapPolicy(GapPolicy(9)).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		}, {
+			name: "no timestamp",
+			run: func() error {
+				_, err := ByTimestamp(true).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		}, {
+			name: "hashed without a key",
+			run: func() error {
+				_, err := ByTimestamp(true).SetMinuteHash(0, 59).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		}, {
+			name: "calendar date which does not exist",
+			run: func() error {
+				_, err := ByFreq(true).AddDay(1).SetCalendar(NewCalendar(nil).ExcludeDate(2020, February, 30)).NextAfter(start)
+				return err
+			},
+			want: ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package schedule
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only moves when it is advanced by hand.
+// Timers, tickers and After channels created by the clock fire as the clock passes their deadline.
+// It is safe for concurrent use.
+//
+// eg:
+//	...
+//	c := schedule.NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+//	t := schedule.ByTimestamp(true).SetClock(c)
+//	...
+//	c.Advance(time.Hour)   // will fire every timer of the clock due within the next hour.
+//	...
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a timer or ticker created by a FakeClock.
+type fakeWaiter struct {
+	clock  *FakeClock
+	at     time.Time     // time at which the waiter fires next.
+	period time.Duration // interval between ticks, 0 for single event timers.
+	c      chan time.Time
+}
+
+// NewFakeClock returns a new fake clock set to the given time.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the current time of the fake clock.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer creates a timer which fires once the clock is advanced by at least duration d.
+func (f *FakeClock) NewTimer(d time.Duration) ClockTimer {
+	return f.wait(d, 0)
+}
+
+// NewTicker creates a ticker which fires every time the clock is advanced past duration d.
+// It panics if d is not positive, as time.NewTicker does.
+func (f *FakeClock) NewTicker(d time.Duration) ClockTicker {
+	if d <= 0 {
+		panic("schedule: non-positive interval for FakeClock.NewTicker")
+	}
+	return fakeTicker{f.wait(d, d)}
+}
+
+// After waits for the clock to be advanced by duration d and then sends the time on the returned channel.
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	return f.wait(d, 0).c
+}
+
+// Advance moves the clock forward by duration d, firing every timer and ticker which is due.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves the clock to the given time, firing every timer and ticker which is due.
+// Setting the clock backwards does not fire anything.
+func (f *FakeClock) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+	f.fire()
+}
+
+// Waiters returns the number of timers and tickers which are waiting for the clock to advance.
+func (f *FakeClock) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// wait registers a new waiter due after duration d.
+func (f *FakeClock) wait(d, period time.Duration) *fakeWaiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &fakeWaiter{clock: f, at: f.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	f.fire()
+	return w
+}
+
+// fire sends the time on the channel of every waiter which is due, in the order of their deadline.
+// Single event timers are removed once fired, tickers are re-armed for their next tick.
+// f.mu must be held.
+func (f *FakeClock) fire() {
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool { return f.waiters[i].at.Before(f.waiters[j].at) })
+		if len(f.waiters) == 0 || f.waiters[0].at.After(f.now) {
+			return
+		}
+
+		w := f.waiters[0]
+		select {
+		case w.c <- w.at:
+		default: // as with time.Ticker, ticks are dropped for slow receivers.
+		}
+		if w.period > 0 {
+			// skip the ticks which were dropped while the clock jumped ahead.
+			w.at = w.at.Add(w.period * (f.now.Sub(w.at)/w.period + 1))
+			continue
+		}
+		f.waiters = f.waiters[1:]
+	}
+}
+
+// remove unregisters the waiter, reporting whether it was still waiting.
+// f.mu must be held.
+func (f *FakeClock) remove(w *fakeWaiter) bool {
+	for i, v := range f.waiters {
+		if v == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// C returns the channel on which the time is delivered.
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+// Stop prevents the waiter from firing, reporting whether it was still waiting.
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.remove(w)
+}
+
+// Reset changes the waiter to fire after duration d, reporting whether it was still waiting.
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.clock.remove(w)
+	w.at = w.clock.now.Add(d)
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.waiters = append(w.clock.waiters, w)
+	w.clock.fire()
+	return active
+}
+
+// fakeTicker adapts the waiter to the ClockTicker interface, whose methods return nothing.
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// Stop turns off the ticker.
+func (t fakeTicker) Stop() {
+	t.fakeWaiter.Stop()
+}
+
+// Reset stops the ticker and resets its period to duration d.
+func (t fakeTicker) Reset(d time.Duration) {
+	t.fakeWaiter.Reset(d)
+}
@@ -0,0 +1,89 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// received returns the value waiting on the channel, if any.
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case v := <-c:
+		return v, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeClock_Advance(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Minute)
+	after := c.After(2 * time.Minute)
+	ticker := c.NewTicker(30 * time.Second)
+
+	c.Advance(30 * time.Second)
+	if got := c.Now(); !got.Equal(start.Add(30 * time.Second)) {
+		t.Errorf("FakeClock.Now() = %v, want %v", got, start.Add(30*time.Second))
+	}
+	if _, ok := received(timer.C()); ok {
+		t.Errorf("FakeClock.NewTimer() fired before its deadline")
+	}
+	if got, ok := received(ticker.C()); !ok || !got.Equal(start.Add(30*time.Second)) {
+		t.Errorf("FakeClock.NewTicker() = %v, %v, want %v", got, ok, start.Add(30*time.Second))
+	}
+
+	c.Advance(30 * time.Second)
+	if got, ok := received(timer.C()); !ok || !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("FakeClock.NewTimer() = %v, %v, want %v", got, ok, start.Add(time.Minute))
+	}
+
+	// ticks missed by a slow receiver are dropped.
+	c.Advance(5 * time.Minute)
+	if got, ok := received(ticker.C()); !ok || !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("FakeClock.NewTicker() = %v, %v, want %v", got, ok, start.Add(time.Minute))
+	}
+	if _, ok := received(ticker.C()); ok {
+		t.Errorf("FakeClock.NewTicker() buffered more than a single tick")
+	}
+	if got, ok := received(after); !ok || !got.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("FakeClock.After() = %v, %v, want %v", got, ok, start.Add(2*time.Minute))
+	}
+	if got := c.Waiters(); got != 1 {
+		t.Errorf("FakeClock.Waiters() = %v, want 1", got)
+	}
+
+	ticker.Stop()
+	if got := c.Waiters(); got != 0 {
+		t.Errorf("FakeClock.Waiters() = %v, want 0", got)
+	}
+}
+
+func TestFakeClock_Reset(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Hour)
+	if !timer.Stop() {
+		t.Errorf("FakeClock timer Stop() = false, want true for an active timer")
+	}
+	if timer.Stop() {
+		t.Errorf("FakeClock timer Stop() = true, want false for a stopped timer")
+	}
+	if timer.Reset(time.Second) {
+		t.Errorf("FakeClock timer Reset() = true, want false for a stopped timer")
+	}
+
+	c.Set(start.Add(time.Second))
+	if got, ok := received(timer.C()); !ok || !got.Equal(start.Add(time.Second)) {
+		t.Errorf("FakeClock timer = %v, %v, want %v", got, ok, start.Add(time.Second))
+	}
+
+	// timers which are already due fire immediately.
+	if _, ok := received(c.After(0)); !ok {
+		t.Errorf("FakeClock.After(0) did not fire")
+	}
+}
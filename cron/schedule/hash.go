@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// hashRange is the range of values a time unit of a Timer is hashed into, in the manner of the H of Jenkins.
+// The time unit runs once within the range, or every step values from a hashed offset when step is set.
+type hashRange struct {
+	from, to int
+	step     int
+}
+
+// SetHashKey sets the key the hashed time units of the scheduler are derived from, eg: the name of the job.
+// The same key always gives the same schedule, while different keys are spread over the hashed ranges.
+//
+// eg:
+//	...
+//	t.SetMinuteHash(0, 59).SetHashKey("backup")   // will run the scheduler once an hour, at a minute which only depends on "backup".
+//	...
+func (t Timer) SetHashKey(key string) Timer {
+	t.dur.hashKey = key
+	return t
+}
+
+// SetMonthHash sets the scheduler to run in a single month within the given months, derived from the hash key.
+// With a step, it runs every step months from a month derived from the hash key within the first step months.
+//
+// eg:
+//	...
+//	t.SetMonthHash(schedule.January, schedule.December, 3)   // will run the scheduler once a quarter, in the same month of each quarter.
+//	...
+func (t Timer) SetMonthHash(from, to Month, step ...int) Timer {
+	t.setHash(month, int(from), int(to), step)
+	return t
+}
+
+// SetDateHash sets the scheduler to run on a single date within the given dates, derived from the hash key.
+// With a step, it runs every step dates from a date derived from the hash key within the first step dates.
+//
+// eg:
+//	...
+//	t.SetDateHash(1, 28)   // will run the scheduler once a month, on a date which exists in every month.
+//	...
+func (t Timer) SetDateHash(from, to int, step ...int) Timer {
+	t.setHash(date, from, to, step)
+	return t
+}
+
+// SetDayHash sets the scheduler to run on a single weekday within the given weekdays, derived from the hash key.
+// With a step, it runs every step weekdays from a weekday derived from the hash key within the first step weekdays.
+//
+// eg:
+//	...
+//	t.SetDayHash(schedule.Monday, schedule.Friday)   // will run the scheduler once a week, on a business day.
+//	...
+func (t Timer) SetDayHash(from, to Weekday, step ...int) Timer {
+	t.setHash(day, int(from), int(to), step)
+	return t
+}
+
+// SetHourHash sets the scheduler to run on a single hour within the given hours, derived from the hash key.
+// With a step, it runs every step hours from an hour derived from the hash key within the first step hours.
+//
+// eg:
+//	...
+//	t.SetHourHash(0, 5)   // will run the scheduler once a day, at night.
+//	...
+func (t Timer) SetHourHash(from, to int, step ...int) Timer {
+	t.setHash(hour, from, to, step)
+	return t
+}
+
+// SetMinuteHash sets the scheduler to run on a single minute within the given minutes, derived from the hash key.
+// With a step, it runs every step minutes from a minute derived from the hash key within the first step minutes.
+//
+// eg:
+//	...
+//	t.SetMinuteHash(0, 59, 15)   // will run the scheduler every 15 minutes, eg: at 7, 22, 37 and 52 past the hour.
+//	...
+func (t Timer) SetMinuteHash(from, to int, step ...int) Timer {
+	t.setHash(minute, from, to, step)
+	return t
+}
+
+// SetSecondHash sets the scheduler to run on a single second within the given seconds, derived from the hash key.
+// With a step, it runs every step seconds from a second derived from the hash key within the first step seconds.
+//
+// eg:
+//	...
+//	t.SetSecondHash(0, 59)   // will run the scheduler once a minute.
+//	...
+func (t Timer) SetSecondHash(from, to int, step ...int) Timer {
+	t.setHash(second, from, to, step)
+	return t
+}
+
+// setHash sets the time unit to be derived from the hash key within the range, replacing the previous values.
+func (t *Timer) setHash(units timeUnit, from, to int, step []int) {
+	lim := limits[units]
+	for _, v := range []int{from, to} {
+		if v < lim[0] || v > lim[1] {
+			t.invalidField("hashed "+units.String(), v, lim[0], lim[1])
+			return
+		}
+	}
+	if from > to {
+		t.invalid("hashed %s range must not end before it starts, given %d-%d", units, from, to)
+		return
+	}
+	r := hashRange{from: from, to: to, step: to - from + 1}
+	if len(step) > 0 {
+		if step[0] <= 0 {
+			t.invalid("hashed %s step must be positive, given %d", units, step[0])
+			return
+		}
+		r.step = step[0]
+	}
+
+	delete(t.dur.sets, units)
+	if t.dur.hashes == nil {
+		t.dur.hashes = map[timeUnit]hashRange{}
+	}
+	t.dur.hashes[units] = r
+}
+
+// resolveHashes sets the values of the hashed time units, derived from the hash key, on a new map of the values,
+// so the map shared by the copies of the Timer is never written while scheduling.
+func (d *duration) resolveHashes() error {
+	if len(d.hashes) == 0 {
+		return nil
+	}
+	if d.hashKey == "" {
+		return fmt.Errorf("%w: hashed time units need a key to be derived from, see SetHashKey", ErrInvalid)
+	}
+	sets := make(map[timeUnit][]int, len(d.sets)+len(d.hashes))
+	for units, vals := range d.sets {
+		sets[units] = vals
+	}
+	for units, r := range d.hashes {
+		sets[units] = r.values(hashOf(d.hashKey, units))
+	}
+	d.sets = sets
+	return nil
+}
+
+// values returns the values of the range, starting from the offset derived from the hash.
+func (r hashRange) values(h uint32) (vals []int) {
+	span := r.step
+	if n := r.to - r.from + 1; n < span {
+		span = n
+	}
+	for v := r.from + int(h%uint32(span)); v <= r.to; v += r.step {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// hashOf hashes the key for the time unit, so the time units of the same key are spread independently.
+func hashOf(key string, units timeUnit) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key + "/" + units.String()))
+	return h.Sum32()
+}
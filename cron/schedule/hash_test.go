@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_hashRange_values(t *testing.T) {
+	tests := []struct {
+		name string
+		r    hashRange
+		h    uint32
+		want []int
+	}{
+		{
+			name: "single value",
+			r:    hashRange{from: 0, to: 59, step: 60},
+			h:    125,
+			want: []int{5},
+		}, {
+			name: "single value in range",
+			r:    hashRange{from: 10, to: 19, step: 10},
+			h:    23,
+			want: []int{13},
+		}, {
+			name: "step",
+			r:    hashRange{from: 0, to: 59, step: 15},
+			h:    22,
+			want: []int{7, 22, 37, 52},
+		}, {
+			name: "step larger than range",
+			r:    hashRange{from: 1, to: 5, step: 10},
+			h:    7,
+			want: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.values(tt.h); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hashRange.values() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_SetHashKey(t *testing.T) {
+	hourly := func(key string) Timer {
+		return ByTimestamp(true).SetClock(clk).SetMinuteHash(0, 59).SetSeconds(0).SetHashKey(key)
+	}
+	after := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// the same key always gives the same schedule.
+	first, err := hourly("backup").NextAfter(after)
+	if err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+	for n := 0; n < 3; n++ {
+		if got, _ := hourly("backup").NextAfter(after); !got.Equal(first) {
+			t.Errorf("Timer.NextAfter() = %v, want %v for the same key", got, first)
+		}
+	}
+
+	// different keys are spread over the range.
+	minutes := map[int]bool{}
+	for n := 0; n < 100; n++ {
+		got, err := hourly(fmt.Sprintf("job-%d", n)).NextAfter(after)
+		if err != nil {
+			t.Fatalf("Timer.NextAfter() error = %v", err)
+		}
+		if got.Hour() > 0 && got.Minute() != 0 {
+			t.Errorf("Timer.NextAfter() = %v, want within the next hour", got)
+		}
+		minutes[got.Minute()] = true
+	}
+	if len(minutes) < 30 {
+		t.Errorf("Timer.NextAfter() spread 100 keys over %d minutes, want at least 30", len(minutes))
+	}
+}
+
+func TestTimer_SetMinuteHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		unit    timeUnit
+		within  [2]int
+		count   int
+		wantErr bool
+	}{
+		{
+			name:   "minute",
+			build:  func(t Timer) Timer { return t.SetMinuteHash(0, 59).SetHashKey("report") },
+			unit:   minute,
+			within: [2]int{0, 59},
+			count:  1,
+		}, {
+			name:   "minute step",
+			build:  func(t Timer) Timer { return t.SetMinuteHash(0, 59, 15).SetHashKey("report") },
+			unit:   minute,
+			within: [2]int{0, 59},
+			count:  4,
+		}, {
+			name:   "night hour",
+			build:  func(t Timer) Timer { return t.SetHourHash(0, 5).SetHashKey("report") },
+			unit:   hour,
+			within: [2]int{0, 5},
+			count:  1,
+		}, {
+			name:   "business day",
+			build:  func(t Timer) Timer { return t.SetDayHash(Monday, Friday).SetHashKey("report") },
+			unit:   day,
+			within: [2]int{1, 5},
+			count:  1,
+		}, {
+			name:   "replaced by values",
+			build:  func(t Timer) Timer { return t.SetDateHash(1, 28).SetDates(15).SetHashKey("report") },
+			unit:   date,
+			within: [2]int{15, 15},
+			count:  1,
+		}, {
+			name:    "no key",
+			build:   func(t Timer) Timer { return t.SetSecondHash(0, 59) },
+			wantErr: true,
+		}, {
+			name:    "out of range",
+			build:   func(t Timer) Timer { return t.SetMonthHash(January, Month(13)).SetHashKey("report") },
+			wantErr: true,
+		}, {
+			name:    "invalid step",
+			build:   func(t Timer) Timer { return t.SetMinuteHash(0, 59, 0).SetHashKey("report") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk))
+			err := tr.dur.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("duration.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			vals := tr.dur.values(tt.unit)
+			if len(vals) != tt.count {
+				t.Errorf("Timer %s values = %v, want %d values", tt.unit, vals, tt.count)
+			}
+			for _, v := range vals {
+				if v < tt.within[0] || v > tt.within[1] {
+					t.Errorf("Timer %s values = %v, want within %v", tt.unit, vals, tt.within)
+				}
+			}
+		})
+	}
+}
+
+func TestTimer_NextAfter_hashedConcurrently(t *testing.T) {
+	tr := ByTimestamp(true).SetMinuteHash(0, 59).SetSecondHash(0, 59).SetHashKey("backup")
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	want, err := tr.NextAfter(start)
+	if err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+
+	// the copies of the Timer share its duration, which scheduling must not write.
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		wg.Add(1)
+		go func(tr Timer) {
+			defer wg.Done()
+			for k := 0; k < 50; k++ {
+				if got, err := tr.NextAfter(start); err != nil || !got.Equal(want) {
+					t.Errorf("Timer.NextAfter() = %v, %v, want %v", got, err, want)
+					return
+				}
+			}
+		}(tr)
+	}
+	wg.Wait()
+	if tr.dur.sets != nil {
+		t.Errorf("Timer.NextAfter() resolved the hashed time units on the shared duration: %v", tr.dur.sets)
+	}
+}
@@ -0,0 +1,292 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportICS excludes the events of an RFC 5545 iCalendar stream, eg: a holiday calendar exported from a calendar app.
+// Each VEVENT excludes the time from its DTSTART to its DTEND, or for its DURATION, all-day events excluding whole days.
+// Floating times and dates are read in the location of the calendar, times with a TZID in the named IANA location.
+// Cancelled events are left out, and recurring events are not supported.
+//
+// eg:
+//	...
+//	f, _ := os.Open("holidays.ics")
+//	defer f.Close()
+//	err := cal.ImportICS(f)   // will exclude every holiday of the file.
+//	...
+func (c *Calendar) ImportICS(r io.Reader) error {
+	var (
+		stack []string  // components the current line is nested in.
+		ev    *icsEvent // event being read.
+		num   int       // number of the current line.
+	)
+	lines := icsLines(r)
+	for lines.Scan() {
+		num = lines.num
+		name, params, value, err := icsProp(lines.Text())
+		if err != nil {
+			return fmt.Errorf("ics line %d: %v", num, err)
+		}
+
+		switch name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(value))
+			if len(stack) == 2 && stack[0] == "VCALENDAR" && stack[1] == "VEVENT" {
+				ev = &icsEvent{line: num}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(value) {
+				return fmt.Errorf("ics line %d: unexpected END:%s", num, value)
+			}
+			stack = stack[:len(stack)-1]
+			if ev != nil && len(stack) == 1 {
+				if err := c.addEvent(ev); err != nil {
+					return fmt.Errorf("ics line %d: %v", ev.line, err)
+				}
+				ev = nil
+			}
+			continue
+		}
+
+		// only the properties of the events themselves are read, not the ones of their alarms.
+		if ev == nil || len(stack) != 2 {
+			continue
+		}
+		if err := ev.set(name, params, value, c.location); err != nil {
+			return fmt.Errorf("ics line %d: %s: %v", num, name, err)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return err
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("ics line %d: missing END:%s", num, stack[len(stack)-1])
+	}
+	return nil
+}
+
+// ImportICSFile excludes the events of the RFC 5545 iCalendar file with the given name, see ImportICS.
+//
+// eg:
+//	...
+//	err := cal.ImportICSFile("holidays.ics")   // will exclude every holiday of the file.
+//	...
+func (c *Calendar) ImportICSFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.ImportICS(f)
+}
+
+// icsEvent is the span of a VEVENT, as read from its properties.
+type icsEvent struct {
+	line      int           // line of the BEGIN:VEVENT, for error messages.
+	start     time.Time     // DTSTART.
+	end       time.Time     // DTEND, zero when not given.
+	dur       time.Duration // DURATION, zero when not given.
+	days      int           // days of the DURATION, which are counted as calendar days.
+	allDay    bool          // if true, DTSTART is a date rather than a date and time.
+	cancelled bool          // if true, the event is left out.
+}
+
+// set reads a property of the event.
+func (ev *icsEvent) set(name string, params map[string]string, value string, loc *time.Location) (err error) {
+	switch name {
+	case "DTSTART":
+		ev.start, ev.allDay, err = icsTime(params, value, loc)
+	case "DTEND":
+		ev.end, _, err = icsTime(params, value, loc)
+	case "DURATION":
+		ev.days, ev.dur, err = icsDuration(value)
+	case "STATUS":
+		ev.cancelled = strings.EqualFold(value, "CANCELLED")
+	case "RRULE", "RDATE":
+		err = fmt.Errorf("recurring events are not supported")
+	}
+	return
+}
+
+// addEvent excludes the span of the event.
+func (c *Calendar) addEvent(ev *icsEvent) error {
+	if ev.start.IsZero() {
+		return fmt.Errorf("VEVENT without DTSTART")
+	}
+	if ev.cancelled {
+		return nil
+	}
+
+	end := ev.end
+	switch {
+	case !end.IsZero():
+	case ev.days != 0 || ev.dur != 0:
+		end = ev.start.AddDate(0, 0, ev.days).Add(ev.dur)
+	case ev.allDay:
+		end = ev.start.AddDate(0, 0, 1)
+	default:
+		// an event without an end which is not all-day takes no time, there is nothing to exclude.
+		return nil
+	}
+	if !end.After(ev.start) {
+		return fmt.Errorf("VEVENT ends at %s, before it starts at %s", end.Format(time.RFC3339), ev.start.Format(time.RFC3339))
+	}
+	c.add(ev.start, end)
+	return nil
+}
+
+// icsScanner reads the content lines of an iCalendar stream, unfolding the lines split over several.
+type icsScanner struct {
+	*bufio.Scanner
+	next    string // physical line read ahead, which starts the next content line.
+	pending bool   // if true, next holds a line read ahead.
+	num     int    // number of the physical line the current content line starts on.
+	at      int    // number of physical lines read.
+	text    string // current content line.
+}
+
+// icsLines returns a scanner over the content lines of the stream.
+func icsLines(r io.Reader) *icsScanner {
+	return &icsScanner{Scanner: bufio.NewScanner(r)}
+}
+
+// Scan moves to the next content line, skipping the empty ones.
+func (s *icsScanner) Scan() bool {
+	for {
+		var line string
+		if s.pending {
+			line, s.pending = s.next, false
+		} else if s.Scanner.Scan() {
+			s.at++
+			line = strings.TrimRight(s.Scanner.Text(), "\r")
+		} else {
+			return false
+		}
+		if line == "" {
+			continue
+		}
+		s.num, s.text = s.at, line
+
+		// a line starting with a space or a tab continues the previous one.
+		for s.Scanner.Scan() {
+			s.at++
+			more := strings.TrimRight(s.Scanner.Text(), "\r")
+			if more == "" || (more[0] != ' ' && more[0] != '\t') {
+				s.next, s.pending = more, true
+				break
+			}
+			s.text += more[1:]
+		}
+		return true
+	}
+}
+
+// Text returns the current content line.
+func (s *icsScanner) Text() string {
+	return s.text
+}
+
+// icsProp splits a content line into its upper cased name, its parameters and its value.
+// eg: DTSTART;TZID=America/New_York:20200101T093000
+func icsProp(line string) (name string, params map[string]string, value string, err error) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("missing : in %q", line)
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	name, value = strings.ToUpper(parts[0]), line[colon+1:]
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return "", nil, "", fmt.Errorf("invalid parameter %q", p)
+		}
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return
+}
+
+// icsTime parses a DATE or DATE-TIME value, reporting whether it is a date.
+// UTC times end with Z, times with a TZID parameter are read in the named location, others in loc.
+func icsTime(params map[string]string, value string, loc *time.Location) (t time.Time, date bool, err error) {
+	if tzid, ok := params["TZID"]; ok {
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return t, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+	}
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == len("20060102"):
+		t, err = time.ParseInLocation("20060102", value, loc)
+		date = true
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return t, false, fmt.Errorf("invalid date %q", value)
+	}
+	return t, date, nil
+}
+
+// icsDuration parses a positive DURATION value into its days, weeks counting as 7 days, and its time.
+// eg: P1W, P2D, PT1H30M, P1DT12H
+func icsDuration(value string) (days int, dur time.Duration, err error) {
+	s := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, 0, fmt.Errorf("invalid duration %q", value)
+	}
+	inTime := false
+	num := ""
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T' && !inTime && num == "":
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid duration %q", value)
+		}
+		num = ""
+		switch {
+		case r == 'W' && !inTime:
+			days += 7 * n
+		case r == 'D' && !inTime:
+			days += n
+		case r == 'H' && inTime:
+			dur += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			dur += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			dur += time.Duration(n) * time.Second
+		default:
+			return 0, 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if num != "" {
+		return 0, 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return days, dur, nil
+}
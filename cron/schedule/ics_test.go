@@ -0,0 +1,211 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "time/tzdata"
+)
+
+// sameRanges reports whether the ranges span the same instants, whichever their location.
+func sameRanges(got, want []blackout) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if !got[i].start.Equal(want[i].start) || !got[i].end.Equal(want[i].end) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCalendar_ImportICS(t *testing.T) {
+	nyc, _ := time.LoadLocation("America/New_York")
+	tests := []struct {
+		name    string
+		ics     string
+		want    []blackout
+		wantErr string
+	}{
+		{
+			name: "all-day events",
+			ics: `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+SUMMARY:Christmas Day
+DTSTART;VALUE=DATE:20201225
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:Thanksgiving
+DTSTART;VALUE=DATE:20201126
+DTEND;VALUE=DATE:20201128
+END:VEVENT
+END:VCALENDAR`,
+			want: []blackout{
+				{time.Date(2020, time.November, 26, 0, 0, 0, 0, nyc), time.Date(2020, time.November, 28, 0, 0, 0, 0, nyc)},
+				{time.Date(2020, time.December, 25, 0, 0, 0, 0, nyc), time.Date(2020, time.December, 26, 0, 0, 0, 0, nyc)},
+			},
+		}, {
+			name: "timed events",
+			ics: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART:20200301T220000Z\r\n" +
+				"DTEND:20200302T060000Z\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;TZID=\"Europe/Berlin\":20200401T080000\r\n" +
+				"DURATION:PT1H30M\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART:20200501T090000\r\n" +
+				"DURATION:P1DT2H\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []blackout{
+				{time.Date(2020, time.March, 1, 22, 0, 0, 0, time.UTC), time.Date(2020, time.March, 2, 6, 0, 0, 0, time.UTC)},
+				{time.Date(2020, time.April, 1, 6, 0, 0, 0, time.UTC), time.Date(2020, time.April, 1, 7, 30, 0, 0, time.UTC)},
+				{time.Date(2020, time.May, 1, 9, 0, 0, 0, nyc), time.Date(2020, time.May, 2, 11, 0, 0, 0, nyc)},
+			},
+		}, {
+			name: "folded lines, alarms and cancelled events",
+			ics: `BEGIN:VCALENDAR
+BEGIN:VTIMEZONE
+TZID:America/New_York
+BEGIN:STANDARD
+DTSTART:19701101T020000
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+SUMMARY:A long summary folded
+  over two lines
+DTST
+ ART;VALUE=DATE:20200704
+BEGIN:VALARM
+TRIGGER:-PT15M
+DTSTART:20200101T000000Z
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20200705
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:20200706T090000Z
+END:VEVENT
+END:VCALENDAR
+`,
+			want: []blackout{
+				{time.Date(2020, time.July, 4, 0, 0, 0, 0, nyc), time.Date(2020, time.July, 5, 0, 0, 0, 0, nyc)},
+			},
+		}, {
+			name:    "missing start",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: "ics line 2: VEVENT without DTSTART",
+		}, {
+			name:    "recurring event",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20200101T000000Z\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: "ics line 4: RRULE: recurring events are not supported",
+		}, {
+			name:    "unknown time zone",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;TZID=Mars/Olympus:20200101T000000\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: `ics line 3: DTSTART: unknown TZID "Mars/Olympus"`,
+		}, {
+			name:    "invalid date",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2020-01-01\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: `ics line 3: DTSTART: invalid date "2020-01-01"`,
+		}, {
+			name:    "invalid duration",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20200101T000000Z\nDURATION:PT1X\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: `ics line 4: DURATION: invalid duration "PT1X"`,
+		}, {
+			name:    "end before start",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20200102T000000Z\nDTEND:20200101T000000Z\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: "ics line 2: VEVENT ends at 2020-01-01T00:00:00Z, before it starts at 2020-01-02T00:00:00Z",
+		}, {
+			name:    "missing end of component",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20200101T000000Z\n",
+			wantErr: "ics line 3: missing END:VEVENT",
+		}, {
+			name:    "mismatched end of component",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+			wantErr: "ics line 3: unexpected END:VCALENDAR",
+		}, {
+			name:    "missing colon",
+			ics:     "BEGIN:VCALENDAR\nBEGIN\n",
+			wantErr: `ics line 2: missing : in "BEGIN"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCalendar(nyc)
+			err := c.ImportICS(strings.NewReader(tt.ics))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Calendar.ImportICS() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Calendar.ImportICS() error = %v", err)
+				return
+			}
+			if !sameRanges(c.ranges, tt.want) {
+				t.Errorf("Calendar.ImportICS() = %v, want %v", c.ranges, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar_ImportICSFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "holidays.ics")
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20200101\nEND:VEVENT\nEND:VCALENDAR\n"
+	if err := os.WriteFile(name, []byte(ics), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCalendar(nil)
+	if err := c.ImportICSFile(name); err != nil {
+		t.Fatalf("Calendar.ImportICSFile() error = %v", err)
+	}
+	if !c.Excludes(time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Calendar.Excludes() = false, want true")
+	}
+	if err := c.ImportICSFile(filepath.Join(t.TempDir(), "missing.ics")); err == nil {
+		t.Errorf("Calendar.ImportICSFile() error = nil, want error")
+	}
+}
+
+func Test_icsDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		days    int
+		dur     time.Duration
+		wantErr bool
+	}{
+		{value: "P1W", days: 7},
+		{value: "P2D", days: 2},
+		{value: "+PT1H30M15S", dur: time.Hour + 30*time.Minute + 15*time.Second},
+		{value: "P1DT12H", days: 1, dur: 12 * time.Hour},
+		{value: "-P1D", wantErr: true},
+		{value: "P1H", wantErr: true},
+		{value: "PT1D", wantErr: true},
+		{value: "P1", wantErr: true},
+		{value: "PT", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			days, dur, err := icsDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("icsDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if days != tt.days || dur != tt.dur {
+				t.Errorf("icsDuration() = %v, %v, want %v, %v", days, dur, tt.days, tt.dur)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package schedule
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 )
 
@@ -14,7 +15,20 @@ type Interval struct {
 // ByFreq returns a new frequency based scheduler.
 func ByFreq(repeat bool, ctx ...context.Context) *Interval {
 	return &Interval{
-		newSched(repeat, setCtx(ctx)),
+		newSched(repeat, setCtx(ctx), systemClock),
+	}
+}
+
+// ByFreqWithClock returns a new frequency based scheduler driven by the given clock, eg: a FakeClock in tests.
+// A nil clock stands for the system clock.
+//
+// eg:
+//	...
+//	i := schedule.ByFreqWithClock(true, schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func ByFreqWithClock(repeat bool, c Clock, ctx ...context.Context) *Interval {
+	return &Interval{
+		newSched(repeat, setCtx(ctx), c),
 	}
 }
 
@@ -139,20 +153,286 @@ func (d *duration) set(units timeUnit, val int) {
 	}
 }
 
+// SetClock sets the clock of the scheduler, which is used to tell the current time and to wait for the schedule.
+// The system clock is used by default, or when the clock is nil.
+//
+// eg:
+//	...
+//	i.SetClock(schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func (i Interval) SetClock(c Clock) Interval {
+	i.setClock(c)
+	return i
+}
+
+// SetLogger sets the logger which receives the runs armed with Next, at LevelDebug. Nothing is logged by default.
+//
+// eg:
+//	...
+//	i.SetLogger(schedule.NewTextLogger(os.Stderr, schedule.LevelDebug))   // will log the runs armed on stderr.
+//	...
+func (i Interval) SetLogger(l Logger) Interval {
+	i.setLogger(l)
+	return i
+}
+
+// SetJitter spreads the runs of the scheduler randomly, up to max before or after their time.
+// It replaces the jitter set with SetJitterPercent. Only the runs armed with Next are spread,
+// NextAfter and Prev keep returning the exact times of the schedule.
+//
+// eg:
+//	...
+//	i.AddMinute(1).SetJitter(5 * time.Second)   // will run the scheduler every 55 to 65 seconds.
+//	...
+func (i Interval) SetJitter(max time.Duration) Interval {
+	i.setJitter(max, 0)
+	return i
+}
+
+// SetJitterPercent spreads the runs of the scheduler randomly, up to the given percentage of the interval,
+// before or after their time. It replaces the jitter set with SetJitter.
+//
+// eg:
+//	...
+//	i.AddMinute(1).SetJitterPercent(10)   // will run the scheduler every 54 to 66 seconds.
+//	...
+func (i Interval) SetJitterPercent(pct float64) Interval {
+	i.setJitter(0, pct)
+	return i
+}
+
+// SetJitterSource sets the source of the random offsets of the runs, eg: a seeded source in tests.
+// The source is not safe for concurrent use, the default source of math/rand is used when none is set.
+//
+// eg:
+//	...
+//	i.SetJitter(time.Second).SetJitterSource(rand.NewSource(42))   // will spread the runs the same way on every start.
+//	...
+func (i Interval) SetJitterSource(src rand.Source) Interval {
+	i.jitter.rand = rand.New(src)
+	return i
+}
+
+// SetAnchor anchors the scheduler at the given time, which is its first run, the next ones following one interval apart.
+// A scheduler which is not anchored runs one interval after the time it is scheduled from.
+//
+// eg:
+//	...
+//	i.AddHour(1).SetAnchor(time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC))   // will run the scheduler at half past every hour.
+//	...
+func (i Interval) SetAnchor(t time.Time) Interval {
+	i.anchor = t
+	return i
+}
+
+// EveryNth sets the scheduler to run only on every nth tick of its interval.
+// The ticks of an anchored scheduler are counted from its anchor, which from replaces when given, see SetAnchor,
+// so restarting the scheduler keeps its cadence. A scheduler which is not anchored runs n intervals after the time
+// it is scheduled from.
+//
+// eg:
+//	...
+//	i.AddHour(1).EveryNth(3, start)   // will run the scheduler every third hour from start.
+//	...
+func (i Interval) EveryNth(n int, from ...time.Time) Interval {
+	i.everyNth(n)
+	if len(from) > 0 {
+		i.anchor = from[0]
+	}
+	return i
+}
+
+// SetCalendar sets the calendar of the dates and time ranges on which the scheduler must not run.
+// An excluded run of an anchored scheduler is skipped for its first run which is not excluded,
+// while a scheduler which is not anchored runs as soon as the excluded range is over.
+//
+// eg:
+//	...
+//	i.AddHour(1).SetCalendar(freeze)   // will run the scheduler every hour, and right after the freeze when it falls within.
+//	...
+func (i Interval) SetCalendar(c *Calendar) Interval {
+	i.calendar = c
+	return i
+}
+
+// StartAt sets the time before which the scheduler does not run, its first run being at or after it.
+// A scheduler which is not anchored yet is anchored at the start, see SetAnchor.
+//
+// eg:
+//	...
+//	i.AddHour(2).StartAt(launch)   // will run the scheduler every 2 hours from the launch.
+//	...
+func (i Interval) StartAt(start time.Time) Interval {
+	i.setStart(start)
+	if i.anchor.IsZero() && i.start.Equal(start) {
+		i.anchor = start
+	}
+	return i
+}
+
+// EndAt sets the time after which the scheduler does not run, its last run being at or before it.
+// Next fails with ErrScheduleExhausted once the end is passed.
+//
+// eg:
+//	...
+//	i.AddHour(2).EndAt(closing)   // will run the scheduler every 2 hours until the closing.
+//	...
+func (i Interval) EndAt(end time.Time) Interval {
+	i.setEnd(end)
+	return i
+}
+
+// MaxRuns sets the number of runs of the scheduler, counting the runs armed with Next.
+// Next fails with ErrScheduleExhausted once the scheduler has run n times.
+//
+// eg:
+//	...
+//	i.AddHour(2).MaxRuns(3)   // will run the scheduler 3 times, 2 hours apart.
+//	...
+func (i Interval) MaxRuns(n int) Interval {
+	i.setMaxRuns(n)
+	return i
+}
+
 // Next finds the next scheduler interval the scheduler and prepare for run
 func (i Interval) Next() (Scheduler, error) {
 
 	// calculate duration to schedule for
-	if dur, err := i.dur.timeUntil(now()); err != nil {
+	now := i.timeSource().Now()
+
+	// a tick run early by the jitter is not armed again, the search goes on from the time it was due at.
+	after := now
+	if !i.anchor.IsZero() {
+		after = later(now, i.due)
+	}
+	next, err := i.NextAfter(after)
+	if err != nil {
+		return nil, err
+	}
+	if err = i.countRun(); err != nil {
 		return nil, err
-	} else {
-		i.interval = time.Unix(0, dur).In(i.dur.location).Sub(now())
-		return &i, nil
 	}
+	_, at := i.arm(now, next)
+	i.interval = at.Sub(now)
+	i.dur.trace("run armed", "now", now, "interval", i.interval)
+	return &i, nil
+}
+
+// NextAfter returns the time one interval after t, or the first run after t of an anchored scheduler.
+// Unlike Next, it neither reads the clock nor prepares the scheduler to run.
+//
+// eg:
+//	...
+//	next, err := i.NextAfter(time.Now())   // will return the time the scheduler would run at.
+//	...
+func (i Interval) NextAfter(t time.Time) (time.Time, error) {
+	return i.boundNext(t, func(t time.Time) (time.Time, error) {
+		next, err := i.nextRun(t)
+		if err != nil {
+			return next, err
+		}
+		if i.anchor.IsZero() {
+			// the runs of a scheduler which is not anchored start over at the end of the excluded range.
+			return i.calendar.skip(next, func(t time.Time) (time.Time, error) { return t.Add(time.Nanosecond), nil })
+		}
+		return i.calendar.skip(next, i.nextRun)
+	})
+}
+
+// nextRun returns the time one interval after t, or the first run after t of an anchored scheduler, regardless of the calendar.
+func (i *Interval) nextRun(t time.Time) (time.Time, error) {
+	if i.dur.err != nil {
+		return time.Time{}, i.dur.err
+	}
+	dur, err := i.dur.timeUntil(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	n := i.nth()
+	if i.anchor.IsZero() {
+		if n > 1 {
+			return i.dur.runAt(t, n), nil
+		}
+		return time.Unix(0, dur).In(i.dur.location), nil
+	}
+	if t.Before(i.anchor) {
+		return i.anchor.In(i.dur.location), nil
+	}
+
+	// the next tick counted from the anchor, moved on to the next nth tick.
+	k := i.dur.lastRun(i.anchor, t) + 1
+	if r := k % n; r != 0 {
+		k += n - r
+	}
+	return i.dur.runAt(i.anchor, k), nil
 }
 
-// timeUntil the duration to schedule for.
-func (d *duration) timeUntil(nextSched time.Time) (dur int64, err error) {
+// Prev returns the time one interval before t, or the last run before t of an anchored scheduler,
+// eg: to tell after a restart whether a run was missed while the scheduler was down.
+//
+// eg:
+//	...
+//	prev, err := i.SetAnchor(start).Prev(time.Now())   // will return the time the scheduler last ran at, or should have.
+//	...
+func (i Interval) Prev(t time.Time) (time.Time, error) {
+	return i.boundPrev(t, func(t time.Time) (time.Time, error) {
+		prev, err := i.prevRun(t)
+		if err != nil {
+			return prev, err
+		}
+		if i.anchor.IsZero() {
+			// the runs of a scheduler which is not anchored start over right before the start of the excluded range.
+			return i.calendar.skipBack(prev, func(t time.Time) (time.Time, error) { return t.Add(-time.Nanosecond), nil })
+		}
+		return i.calendar.skipBack(prev, i.prevRun)
+	})
+}
+
+// prevRun returns the time one interval before t, or the last run before t of an anchored scheduler, regardless of the calendar.
+func (i *Interval) prevRun(t time.Time) (time.Time, error) {
+	if i.dur.err != nil {
+		return time.Time{}, i.dur.err
+	}
+	if _, err := i.dur.timeUntil(t); err != nil {
+		return time.Time{}, err
+	}
+	n := i.nth()
+	if i.anchor.IsZero() {
+		return i.dur.runAt(t, -n), nil
+	}
+	if !i.anchor.Before(t) {
+		return time.Time{}, fmt.Errorf("%w before %s, the scheduler is anchored at %s", ErrNoMatch, t.Format(time.RFC3339), i.anchor.Format(time.RFC3339))
+	}
+	k := i.dur.lastRun(i.anchor, t.Add(-time.Nanosecond))
+	return i.dur.runAt(i.anchor, k-k%n), nil
+}
+
+// runAt returns the k-th run of the interval, starting at the given time.
+func (d *duration) runAt(start time.Time, k int) time.Time {
+	fixed := time.Duration(d.Hour)*time.Hour +
+		time.Duration(d.Minute)*time.Minute +
+		time.Duration(d.Second)*time.Second +
+		time.Duration(d.Nsec)*time.Nanosecond
+	return start.AddDate(k*d.Year, k*d.Month, k*(d.Day+d.date)).Add(time.Duration(k) * fixed).In(d.location)
+}
+
+// lastRun returns the index of the last run at or before t of the interval anchored at the given time.
+func (d *duration) lastRun(anchor, t time.Time) int {
+
+	// estimate the index from the length of the first interval, then adjust it to the months of uneven length.
+	k := int(t.Sub(anchor) / d.runAt(anchor, 1).Sub(anchor))
+	for k > 0 && d.runAt(anchor, k).After(t) {
+		k--
+	}
+	for !d.runAt(anchor, k+1).After(t) {
+		k++
+	}
+	return k
+}
+
+// timeUntil the duration to schedule for, starting at the given time.
+func (d *duration) timeUntil(from time.Time) (dur int64, err error) {
+	nextSched := from
 
 	// add time till next schedule in years, months and days
 	nextSched = nextSched.AddDate(d.Year, d.Month, d.Day+d.date)
@@ -165,8 +445,8 @@ func (d *duration) timeUntil(nextSched time.Time) (dur int64, err error) {
 		Add(time.Duration(d.Nsec) * time.Nanosecond).In(d.location)
 
 	// check if the time is in the past
-	if !nextSched.After(now()) {
-		return dur, fmt.Errorf("time is in the past")
+	if !nextSched.After(from) {
+		return dur, ErrInPast
 	}
 	return nextSched.UnixNano(), err
 }
@@ -180,8 +460,13 @@ func (d *duration) timeUntil(nextSched time.Time) (dur int64, err error) {
 //	...
 // ie, it will run every (10 years, 10 months, 2 weeks, 3 days, 3 hours, 10 minutes, 10 seconds and 1000 nano seconds)
 // until the scheduler is stopped.
+// The jitter range is reported last when the runs are spread, eg: "... -> next execution in 1m2s (jitter ±5s)".
 func (i *Interval) String() string {
-	return fmt.Sprintf(
+	s := fmt.Sprintf(
 		"%dyrs %dmonths %dweeks %ddays %dhrs %dmins %dsecs %dnsecs -> next execution in %s",
 		i.dur.Year, i.dur.Month, i.dur.Week, i.dur.Day, i.dur.Hour, i.dur.Minute, i.dur.Second, i.dur.Nsec, i.interval)
+	if i.jitter.set() {
+		s += fmt.Sprintf(" (jitter %s)", i.jitter)
+	}
+	return s
 }
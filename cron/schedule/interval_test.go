@@ -26,7 +26,8 @@ func TestByFreq(t *testing.T) {
 			want: &Interval{
 				schedule{
 					repeat:      true,
-					timer:       now(),
+					timer:       clk.Now(),
+					clock:       clk,
 					context:     ctx,
 					dur:         &duration{location: time.UTC},
 					schedEveryN: 0,
@@ -38,13 +39,40 @@ func TestByFreq(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ByFreq(tt.args.repeat, tt.args.ctx...); !reflect.DeepEqual(got, tt.want) {
+			if got := ByFreq(tt.args.repeat, tt.args.ctx...).SetClock(clk); !reflect.DeepEqual(&got, tt.want) {
 				t.Errorf("ByFreq() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestByFreqWithClock(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	got := ByFreqWithClock(true, c, ctx)
+	want := &Interval{
+		schedule{
+			repeat:  true,
+			timer:   c.Now(),
+			clock:   c,
+			context: ctx,
+			dur:     &duration{location: time.UTC},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ByFreqWithClock() = %v, want %v", got, want)
+	}
+}
+
+func TestByFreqWithClock_nilClock(t *testing.T) {
+	got := ByFreqWithClock(true, nil)
+	if got.clock != systemClock {
+		t.Errorf("ByFreqWithClock() clock = %v, want the system clock", got.clock)
+	}
+	if _, err := got.AddMinute(1).Next(); err != nil {
+		t.Errorf("Interval.Next() error = %v", err)
+	}
+}
+
 func TestInterval_Next(t *testing.T) {
 	type fields struct {
 		schedule schedule
@@ -59,7 +87,8 @@ func TestInterval_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:      true,
-					timer:       now(),
+					timer:       clk.Now(),
+					clock:       clk,
 					context:     ctx,
 					dur:         &duration{location: time.UTC},
 					schedEveryN: 0,
@@ -73,7 +102,8 @@ func TestInterval_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Hour:     1,
@@ -93,7 +123,8 @@ func TestInterval_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     1,
@@ -146,7 +177,8 @@ func TestInterval_String(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     1,
@@ -165,7 +197,8 @@ func TestInterval_String(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Hour:     1,
@@ -193,3 +226,139 @@ func TestInterval_String(t *testing.T) {
 		})
 	}
 }
+
+func TestInterval_SetClock(t *testing.T) {
+	t.Parallel()
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	i := ByFreq(true).SetClock(c).AddMinute(90)
+
+	c.Advance(time.Hour)
+	got, err := i.Next()
+	if err != nil {
+		t.Fatalf("Interval.Next() error = %v", err)
+	}
+	if want := 90 * time.Minute; got.(*Interval).interval != want {
+		t.Errorf("Interval.Next() = %v, want %v", got.(*Interval).interval, want)
+	}
+}
+
+func TestInterval_NextAfter(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	i := ByFreq(true).SetClock(c).AddDay(1).AddHour(2)
+
+	after := time.Date(2022, time.January, 31, 23, 0, 0, 0, time.UTC)
+	got, err := i.NextAfter(after)
+	if err != nil {
+		t.Fatalf("Interval.NextAfter() error = %v", err)
+	}
+	if want := time.Date(2022, time.February, 2, 1, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Interval.NextAfter() = %v, want %v", got, want)
+	}
+	if c.Waiters() != 0 || i.interval != 0 {
+		t.Errorf("Interval.NextAfter() prepared the scheduler to run")
+	}
+	if _, err := ByFreq(true).NextAfter(after); err == nil {
+		t.Errorf("Interval.NextAfter() error = nil, want error for an empty interval")
+	}
+}
+
+func TestInterval_Prev(t *testing.T) {
+	anchor := time.Date(2020, time.January, 31, 0, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		i       Interval
+		at      time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "not anchored",
+			i:    ByFreq(true).AddDay(1).AddHour(2),
+			at:   time.Date(2020, time.March, 1, 1, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.February, 28, 23, 0, 0, 0, time.UTC),
+		}, {
+			name: "anchored",
+			i:    ByFreq(true).AddHour(1).SetAnchor(anchor),
+			at:   time.Date(2020, time.February, 2, 10, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.February, 2, 9, 30, 0, 0, time.UTC),
+		}, {
+			name: "anchored on a run",
+			i:    ByFreq(true).AddHour(1).SetAnchor(anchor),
+			at:   time.Date(2020, time.February, 2, 9, 30, 0, 0, time.UTC),
+			want: time.Date(2020, time.February, 2, 8, 30, 0, 0, time.UTC),
+		}, {
+			name: "anchored months",
+			i:    ByFreq(true).AddMonth(1).SetAnchor(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)),
+			at:   time.Date(2030, time.June, 15, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2030, time.June, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:    "before anchor",
+			i:       ByFreq(true).AddHour(1).SetAnchor(anchor),
+			at:      anchor,
+			wantErr: true,
+		}, {
+			name:    "no interval",
+			i:       ByFreq(true).SetAnchor(anchor),
+			at:      anchor.Add(time.Hour),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.Prev(tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Interval.Prev() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Interval.Prev() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterval_NextAfter_anchored(t *testing.T) {
+	anchor := time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		i     Interval
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "before anchor",
+			i:     ByFreq(true).AddHour(1).SetAnchor(anchor),
+			after: anchor.Add(-time.Minute),
+			want:  anchor,
+		}, {
+			name:  "on anchor",
+			i:     ByFreq(true).AddHour(1).SetAnchor(anchor),
+			after: anchor,
+			want:  anchor.Add(time.Hour),
+		}, {
+			name:  "aligned on anchor",
+			i:     ByFreq(true).AddHour(1).SetAnchor(anchor),
+			after: time.Date(2021, time.May, 5, 17, 45, 0, 0, time.UTC),
+			want:  time.Date(2021, time.May, 5, 18, 30, 0, 0, time.UTC),
+		}, {
+			name:  "months of uneven length",
+			i:     ByFreq(true).AddMonth(1).AddDay(1).SetAnchor(anchor),
+			after: time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.December, 12, 0, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.i.NextAfter(tt.after)
+			if err != nil {
+				t.Errorf("Interval.NextAfter() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Interval.NextAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
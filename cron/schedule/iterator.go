@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// NextN returns the next n times after the given time at which the scheduler runs, in increasing order.
+// The scheduler itself is left untouched, which allows previewing its upcoming runs.
+// When the scheduler stops matching before n times are found, the times found so far are returned with the error.
+// A negative n is an error.
+//
+// eg:
+//	...
+//	times, err := schedule.NextN(t, time.Now(), 5)   // will return the next 5 runs of the scheduler.
+//	...
+func NextN(s Scheduler, after time.Time, n int) ([]time.Time, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w: number of runs must not be negative, given %d", ErrInvalid, n)
+	}
+	times := make([]time.Time, 0, n)
+	it := Iterate(s, after)
+	for len(times) < n && it.Next() {
+		times = append(times, it.Time())
+	}
+	return times, it.Err()
+}
+
+// Between returns every time within [start, end) at which the scheduler runs, in increasing order.
+// The scheduler itself is left untouched.
+// When the scheduler stops matching before end, the times found so far are returned with the error.
+//
+// eg:
+//	...
+//	times, err := schedule.Between(t, monday, monday.AddDate(0, 0, 7))   // will return the runs of the scheduler during the week.
+//	...
+func Between(s Scheduler, start, end time.Time) ([]time.Time, error) {
+	var times []time.Time
+
+	// start itself is included when the scheduler runs on it.
+	if first, err := nextAfter(s, start.Add(-time.Nanosecond)); err == nil && first.Equal(start) && start.Before(end) {
+		times = append(times, start)
+	}
+	it := Iterate(s, start)
+	for it.Next() && it.Time().Before(end) {
+		times = append(times, it.Time())
+	}
+	return times, it.Err()
+}
+
+// Iterator walks lazily through the times at which a scheduler runs, in the manner of bufio.Scanner.
+//
+// eg:
+//	...
+//	it := schedule.Iterate(t, time.Now())
+//	for it.Next() {
+//		fmt.Println(it.Time())   // will print the runs of the scheduler, one at a time.
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//	...
+type Iterator struct {
+	sched Scheduler
+	at    time.Time // time of the current run, or the time to start after before the first call to Next.
+	err   error     // error which stopped the iteration.
+}
+
+// Iterate returns an iterator over the times after the given time at which the scheduler runs.
+// The iteration fails with ErrInvalid for a scheduler which is not a Previewer.
+func Iterate(s Scheduler, after time.Time) *Iterator {
+	return &Iterator{sched: s, at: after}
+}
+
+// Next moves the iterator to the next run of the scheduler.
+// It returns false when the scheduler has no more runs or an error occurred, see Err.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	next, err := nextAfter(it.sched, it.at)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.at = next
+	return true
+}
+
+// Time returns the time of the current run of the scheduler.
+func (it *Iterator) Time() time.Time {
+	return it.at
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// nextAfter returns the first run after t of the scheduler, which must be a Previewer to tell it without running.
+func nextAfter(s Scheduler, t time.Time) (time.Time, error) {
+	p, ok := s.(Previewer)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %T does not tell its runs without running, it is not a Previewer", ErrInvalid, s)
+	}
+	return p.NextAfter(t)
+}
@@ -0,0 +1,184 @@
+package schedule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNextN(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	hourly := ByTimestamp(true).SetMinutes(0).SetSeconds(0)
+	every := ByFreq(true).AddMinute(90)
+	once := ByTimestamp(false).SetYear(2020).SetMonth(December).SetDate(31).SetHour(23).SetMinute(59).SetSecond(59)
+
+	tests := []struct {
+		name    string
+		s       Scheduler
+		after   time.Time
+		n       int
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name:  "timer",
+			s:     &hourly,
+			after: start,
+			n:     3,
+			want: []time.Time{
+				start.Add(time.Hour),
+				start.Add(2 * time.Hour),
+				start.Add(3 * time.Hour),
+			},
+		}, {
+			name:  "interval",
+			s:     &every,
+			after: start,
+			n:     2,
+			want: []time.Time{
+				start.Add(90 * time.Minute),
+				start.Add(180 * time.Minute),
+			},
+		}, {
+			name:  "none",
+			s:     &hourly,
+			after: start,
+			n:     0,
+			want:  []time.Time{},
+		}, {
+			name:    "negative",
+			s:       &hourly,
+			after:   start,
+			n:       -1,
+			wantErr: true,
+		}, {
+			name:  "exhausted",
+			s:     &once,
+			after: start,
+			n:     2,
+			want: []time.Time{
+				time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextN(tt.s, tt.after, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NextN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	start := time.Date(2020, time.January, 6, 0, 0, 0, 0, time.UTC)
+	mornings := ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0).SetDayRange(Monday, Friday)
+	every := ByFreq(true).AddHour(12)
+
+	tests := []struct {
+		name  string
+		s     Scheduler
+		start time.Time
+		end   time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "week days",
+			s:     &mornings,
+			start: start,
+			end:   start.AddDate(0, 0, 7),
+			want: []time.Time{
+				start.Add(9 * time.Hour),
+				start.AddDate(0, 0, 1).Add(9 * time.Hour),
+				start.AddDate(0, 0, 2).Add(9 * time.Hour),
+				start.AddDate(0, 0, 3).Add(9 * time.Hour),
+				start.AddDate(0, 0, 4).Add(9 * time.Hour),
+			},
+		}, {
+			name:  "start included and end excluded",
+			s:     &mornings,
+			start: start.Add(9 * time.Hour),
+			end:   start.AddDate(0, 0, 1).Add(9 * time.Hour),
+			want: []time.Time{
+				start.Add(9 * time.Hour),
+			},
+		}, {
+			name:  "interval",
+			s:     &every,
+			start: start,
+			end:   start.Add(36 * time.Hour),
+			want: []time.Time{
+				start.Add(12 * time.Hour),
+				start.Add(24 * time.Hour),
+			},
+		}, {
+			name:  "empty window",
+			s:     &mornings,
+			start: start,
+			end:   start.Add(time.Hour),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.s, tt.start, tt.end)
+			if err != nil {
+				t.Errorf("Between() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIterate(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	quarters := ByTimestamp(true).SetMinuteStep(15).SetSeconds(0)
+
+	it := Iterate(&quarters, start)
+	for i := 1; i <= 8; i++ {
+		if !it.Next() {
+			t.Fatalf("Iterator.Next() = false, want true, error = %v", it.Err())
+		}
+		if want := start.Add(time.Duration(i) * 15 * time.Minute); !it.Time().Equal(want) {
+			t.Errorf("Iterator.Time() = %v, want %v", it.Time(), want)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Iterator.Err() = %v, want nil", err)
+	}
+
+	it = Iterate(ByTimestamp(true), start)
+	if it.Next() {
+		t.Errorf("Iterator.Next() = true, want false")
+	}
+	if it.Err() == nil {
+		t.Errorf("Iterator.Err() = nil, want error")
+	}
+}
+
+// runOnly is a Scheduler of another package, which does not tell its runs without running.
+type runOnly struct{}
+
+func (runOnly) Next() (Scheduler, error) { return runOnly{}, nil }
+func (runOnly) String() string           { return "run only" }
+
+func TestNextN_notPreviewer(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := NextN(runOnly{}, start, 1); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NextN() error = %v, want %v", err, ErrInvalid)
+	}
+	every := ByFreq(true).AddMinute(90)
+	if _, err := NextN(Union(&every, runOnly{}), start, 1); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NextN() of a Union error = %v, want %v", err, ErrInvalid)
+	}
+}
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// jitter is the random spread of the runs of a scheduler, either way of their time.
+type jitter struct {
+	max  time.Duration // maximum offset of the runs.
+	pct  float64       // maximum offset of the runs, as a percentage of the time left until them.
+	rand *rand.Rand    // source of the offsets, the default source of math/rand when nil.
+}
+
+// setJitter sets the maximum offset of the runs, either as a duration or as a percentage.
+func (s *schedule) setJitter(max time.Duration, pct float64) {
+	switch {
+	case max < 0:
+		s.invalid("jitter must not be negative, given %s", max)
+	case pct < 0 || pct > 100:
+		s.invalid("jitter percentage must be between 0 and 100, given %g", pct)
+	default:
+		s.jitter.max, s.jitter.pct = max, pct
+	}
+}
+
+// spread returns the time of the run due at next, moved by a random offset within the jitter range.
+// The run is never moved to now or before it, nor into a range excluded by the calendar of the scheduler.
+func (s *schedule) spread(now, next time.Time) time.Time {
+	left := next.Sub(now)
+	off := s.jitter.offset(left)
+	if off <= -left {
+		off = time.Nanosecond - left
+	}
+	if s.calendar.Excludes(next.Add(off)) {
+		return next
+	}
+	return next.Add(off)
+}
+
+// later returns the later of the two times.
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// set reports whether the runs are spread.
+func (j jitter) set() bool {
+	return j.max > 0 || j.pct > 0
+}
+
+// limit returns the maximum offset of a run due after the given duration.
+func (j jitter) limit(left time.Duration) time.Duration {
+	if j.pct > 0 {
+		max := float64(left) * j.pct / 100
+		if max >= float64(maxOffset) {
+			return maxOffset
+		}
+		return time.Duration(max)
+	}
+	return j.max
+}
+
+// maxOffset is the largest offset of a run, whose range either way of the run still fits in a time.Duration.
+const maxOffset = time.Duration(math.MaxInt64 / 2)
+
+// offset returns a random offset within the jitter range of a run due after the given duration.
+func (j jitter) offset(left time.Duration) time.Duration {
+	max := j.limit(left)
+	if max <= 0 {
+		return 0
+	}
+	if max > maxOffset {
+		max = maxOffset
+	}
+	return time.Duration(j.int63n(2*int64(max)+1)) - max
+}
+
+// int63n returns a random number in [0, n) from the source of the jitter.
+func (j jitter) int63n(n int64) int64 {
+	if j.rand != nil {
+		return j.rand.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+// String returns the jitter range, eg: ±5s or ±10%.
+func (j jitter) String() string {
+	if j.pct > 0 {
+		return fmt.Sprintf("±%s%%", strconv.FormatFloat(j.pct, 'f', -1, 64))
+	}
+	return fmt.Sprintf("±%s", j.max)
+}
@@ -0,0 +1,172 @@
+package schedule
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func Test_jitter_offset(t *testing.T) {
+	tests := []struct {
+		name string
+		j    jitter
+		left time.Duration
+		max  time.Duration
+	}{
+		{
+			name: "none",
+			j:    jitter{},
+			left: time.Minute,
+			max:  0,
+		}, {
+			name: "absolute",
+			j:    jitter{max: 5 * time.Second},
+			left: time.Minute,
+			max:  5 * time.Second,
+		}, {
+			name: "percentage",
+			j:    jitter{pct: 10},
+			left: time.Minute,
+			max:  6 * time.Second,
+		}, {
+			name: "longest absolute",
+			j:    jitter{max: math.MaxInt64},
+			left: time.Minute,
+			max:  maxOffset,
+		}, {
+			name: "whole percentage of the longest wait",
+			j:    jitter{pct: 100},
+			left: math.MaxInt64,
+			max:  maxOffset,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.j.rand = rand.New(rand.NewSource(1))
+			spread := false
+			for n := 0; n < 100; n++ {
+				got := tt.j.offset(tt.left)
+				if got < -tt.max || got > tt.max {
+					t.Fatalf("jitter.offset() = %v, want within ±%v", got, tt.max)
+				}
+				spread = spread || got != 0
+			}
+			if spread != (tt.max > 0) {
+				t.Errorf("jitter.offset() spread = %v, want %v", spread, tt.max > 0)
+			}
+		})
+	}
+}
+
+func Test_jitter_seeded(t *testing.T) {
+	a := jitter{max: time.Minute, rand: rand.New(rand.NewSource(42))}
+	b := jitter{max: time.Minute, rand: rand.New(rand.NewSource(42))}
+	for n := 0; n < 10; n++ {
+		if got, want := a.offset(time.Hour), b.offset(time.Hour); got != want {
+			t.Fatalf("jitter.offset() = %v, want %v with the same seed", got, want)
+		}
+	}
+}
+
+func Test_schedule_spread(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := &schedule{jitter: jitter{max: time.Hour, rand: rand.New(rand.NewSource(1))}}
+	for n := 0; n < 100; n++ {
+		if got := s.spread(now, now.Add(time.Second)); !got.After(now) {
+			t.Fatalf("schedule.spread() = %v, want after %v", got, now)
+		}
+	}
+}
+
+func TestTimer_SetJitter(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	tr := ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).SetJitter(time.Minute).SetJitterSource(rand.NewSource(1))
+
+	got, err := tr.Next()
+	if err != nil {
+		t.Fatalf("Timer.Next() error = %v", err)
+	}
+	due := time.Date(2021, time.June, 1, 13, 0, 0, 0, time.UTC)
+	if at := got.(*Timer).timer; at.Before(due.Add(-time.Minute)) || at.After(due.Add(time.Minute)) || at.Equal(due) {
+		t.Errorf("Timer.Next() = %v, want within a minute of %v", at, due)
+	}
+	if next, _ := tr.NextAfter(c.Now()); !next.Equal(due) {
+		t.Errorf("Timer.NextAfter() = %v, want %v without jitter", next, due)
+	}
+	if _, err := tr.SetJitter(-time.Minute).Next(); err == nil {
+		t.Errorf("Timer.Next() error = nil, want error for a negative jitter")
+	}
+}
+
+func TestTimer_Next_jitterEarly(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		c := NewFakeClock(time.Date(2021, time.June, 1, 9, 0, 0, 0, time.UTC))
+		tr := ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).SetJitter(10 * time.Minute).SetJitterSource(rand.NewSource(seed))
+		var s Scheduler = &tr
+		for _, due := range []time.Time{
+			time.Date(2021, time.June, 1, 10, 0, 0, 0, time.UTC),
+			time.Date(2021, time.June, 1, 11, 0, 0, 0, time.UTC),
+			time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC),
+		} {
+			var err error
+			if s, err = s.Next(); err != nil {
+				t.Fatalf("Timer.Next() error = %v", err)
+			}
+
+			// the run comes, possibly before it is due, and the next one is armed right away.
+			at := s.(*Timer).timer
+			if at.Before(due.Add(-10*time.Minute)) || at.After(due.Add(10*time.Minute)) {
+				t.Fatalf("Timer.Next() = %v, want within 10 minutes of %v", at, due)
+			}
+			c.Set(at)
+		}
+	}
+}
+
+func TestInterval_SetJitterPercent(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	i := ByFreq(true).SetClock(c).AddMinute(1).SetJitterPercent(10).SetJitterSource(rand.NewSource(1))
+
+	for n := 0; n < 20; n++ {
+		got, err := i.Next()
+		if err != nil {
+			t.Fatalf("Interval.Next() error = %v", err)
+		}
+		if d := got.(*Interval).interval; d < 54*time.Second || d > 66*time.Second {
+			t.Errorf("Interval.Next() = %v, want between 54s and 66s", d)
+		}
+	}
+	if _, err := i.SetJitterPercent(150).Next(); err == nil {
+		t.Errorf("Interval.Next() error = nil, want error for a percentage over 100")
+	}
+}
+
+func TestInterval_String_jitter(t *testing.T) {
+	tests := []struct {
+		name string
+		i    Interval
+		want string
+	}{
+		{
+			name: "absolute",
+			i:    ByFreq(true).AddMinute(1).SetJitter(5 * time.Second),
+			want: "0yrs 0months 0weeks 0days 0hrs 1mins 0secs 0nsecs -> next execution in 0s (jitter ±5s)",
+		}, {
+			name: "percentage",
+			i:    ByFreq(true).AddHour(1).SetJitterPercent(2.5),
+			want: "0yrs 0months 0weeks 0days 1hrs 0mins 0secs 0nsecs -> next execution in 0s (jitter ±2.5%)",
+		}, {
+			name: "none",
+			i:    ByFreq(true).AddHour(1).SetJitter(0),
+			want: "0yrs 0months 0weeks 0days 1hrs 0mins 0secs 0nsecs -> next execution in 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.String(); got != tt.want {
+				t.Errorf("Interval.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the importance of a record logged by the schedulers, modelled on the levels of log/slog.
+type Level int
+
+// Levels of the records, with the values of their log/slog counterparts.
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+// String returns the name of the level, with the offset from the level below it if any, eg: "DEBUG" or "INFO+2".
+func (l Level) String() string {
+	name := func(base string, off Level) string {
+		if off == 0 {
+			return base
+		}
+		return fmt.Sprintf("%s%+d", base, int(off))
+	}
+	switch {
+	case l < LevelInfo:
+		return name("DEBUG", l-LevelDebug)
+	case l < LevelWarn:
+		return name("INFO", l-LevelInfo)
+	case l < LevelError:
+		return name("WARN", l-LevelWarn)
+	}
+	return name("ERROR", l-LevelError)
+}
+
+// Logger receives the records logged by the schedulers, eg: the trace of the search of their runs at LevelDebug.
+// The schedulers log nothing unless a logger is set, see SetLogger.
+//
+// eg:
+//	...
+//	type slogger struct{ *slog.Logger }   // will adapt a *slog.Logger to a schedule.Logger.
+//
+//	func (l slogger) Enabled(level schedule.Level) bool {
+//		return l.Logger.Enabled(context.Background(), slog.Level(level))
+//	}
+//
+//	func (l slogger) Log(level schedule.Level, msg string, args ...interface{}) {
+//		l.Logger.Log(context.Background(), slog.Level(level), msg, args...)
+//	}
+//	...
+type Logger interface {
+	// Enabled reports whether the records of the given level are logged, so the others are not even built.
+	Enabled(level Level) bool
+	// Log logs a record of the given level, its attributes given as alternating keys and values, as with log/slog.
+	Log(level Level, msg string, args ...interface{})
+}
+
+// NewTextLogger returns a Logger writing the records of the given level and above to w, one per line,
+// as key=value pairs in the manner of slog.TextHandler. It is safe for concurrent use.
+//
+// eg:
+//	...
+//	t.SetLogger(schedule.NewTextLogger(os.Stderr, schedule.LevelDebug))
+//	...
+//	// output:
+//	// level=DEBUG msg="candidate rejected" unit=hour candidate=2020-01-01T10:00:00Z reason="hour is not allowed"
+//	...
+func NewTextLogger(w io.Writer, level Level) Logger {
+	return &textLogger{w: w, level: level}
+}
+
+// textLogger writes the records as key=value pairs.
+type textLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level // lowest level of the records written.
+}
+
+// Enabled reports whether the level is at or above the level of the logger.
+func (l *textLogger) Enabled(level Level) bool {
+	return level >= l.level
+}
+
+// Log writes the record on a single line, skipping it when its level is not enabled.
+func (l *textLogger) Log(level Level, msg string, args ...interface{}) {
+	if !l.Enabled(level) {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("level=" + level.String() + " msg=" + quote(msg))
+	for i := 0; i < len(args); i += 2 {
+		key, val := "!BADKEY", args[i]
+		if k, ok := args[i].(string); ok && i+1 < len(args) {
+			key, val = k, args[i+1]
+		} else {
+			i-- // a value without a key, as log/slog reports it.
+		}
+		b.WriteString(" " + key + "=" + quote(textOf(val)))
+	}
+	b.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, b.String())
+}
+
+// textOf returns the text of an attribute value, times being written in RFC 3339.
+func textOf(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// quote quotes the text when it would not read as a single value, ie: when empty or made of spaces, quotes or equal signs.
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// setLogger sets the logger of the scheduler, nil to log nothing.
+func (s *schedule) setLogger(l Logger) {
+	s.dur.logger = l
+}
+
+// tracing reports whether the search of the runs is traced, ie: whether a logger is set with LevelDebug enabled.
+func (d *duration) tracing() bool {
+	return d.logger != nil && d.logger.Enabled(LevelDebug)
+}
+
+// trace logs a debug record with the given attributes, when tracing.
+func (d *duration) trace(msg string, args ...interface{}) {
+	if d.tracing() {
+		d.logger.Log(LevelDebug, msg, args...)
+	}
+}
+
+// reject traces a candidate wall time rejected while searching for a run, along with the time unit which rejected it.
+func (d *duration) reject(units timeUnit, candidate duration, reason string) {
+	if d.tracing() {
+		d.logger.Log(LevelDebug, "candidate rejected", "unit", units, "candidate", candidate.wall(), "reason", reason)
+	}
+}
+
+// wall returns the wall time in its location.
+func (d duration) wall() time.Time {
+	return time.Date(d.Year, time.Month(d.Month), d.date, d.Hour, d.Minute, d.Second, 0, d.location)
+}
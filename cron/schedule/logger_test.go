@@ -0,0 +1,141 @@
+package schedule
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// record is a record logged to a recorder.
+type record struct {
+	level Level
+	msg   string
+	args  []interface{}
+}
+
+// recorder is a Logger keeping the records of the given level and above.
+type recorder struct {
+	level   Level
+	records []record
+}
+
+func (r *recorder) Enabled(level Level) bool {
+	return level >= r.level
+}
+
+func (r *recorder) Log(level Level, msg string, args ...interface{}) {
+	r.records = append(r.records, record{level, msg, args})
+}
+
+func TestLevel_String(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  string
+	}{
+		{LevelDebug, "DEBUG"},
+		{LevelInfo, "INFO"},
+		{LevelWarn, "WARN"},
+		{LevelError, "ERROR"},
+		{LevelDebug - 2, "DEBUG-2"},
+		{LevelInfo + 2, "INFO+2"},
+		{LevelError + 1, "ERROR+1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.level.String(); got != tt.want {
+				t.Errorf("Level.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTextLogger(t *testing.T) {
+	at := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		level Level
+		msg   string
+		args  []interface{}
+		want  string
+	}{
+		{
+			name:  "attributes",
+			level: LevelDebug,
+			msg:   "candidate rejected",
+			args:  []interface{}{"unit", hour, "candidate", at, "reason", "hour is not allowed"},
+			want:  "level=DEBUG msg=\"candidate rejected\" unit=hour candidate=2020-01-01T10:00:00Z reason=\"hour is not allowed\"\n",
+		}, {
+			name:  "bad key",
+			level: LevelWarn,
+			msg:   "odd",
+			args:  []interface{}{1, "key", "a=b", "dangling"},
+			want:  "level=WARN msg=odd !BADKEY=1 key=\"a=b\" !BADKEY=dangling\n",
+		}, {
+			name:  "below the level",
+			level: LevelDebug - 1,
+			msg:   "hidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			NewTextLogger(&b, LevelDebug).Log(tt.level, tt.msg, tt.args...)
+			if got := b.String(); got != tt.want {
+				t.Errorf("textLogger.Log() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_SetLogger(t *testing.T) {
+	now := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	rec := &recorder{level: LevelDebug}
+	tr := ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0).SetLogger(rec)
+	if _, err := tr.NextAfter(now); err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+
+	want := []record{
+		{LevelDebug, "searching next run", []interface{}{"after", now}},
+		{LevelDebug, "candidate rejected", []interface{}{"unit", hour, "candidate", now.Add(time.Second), "reason", "no hour is allowed after it in the day"}},
+		{LevelDebug, "candidate rejected", []interface{}{"unit", hour, "candidate", now.Add(14 * time.Hour), "reason", "hour is not allowed"}},
+		{LevelDebug, "next run found", []interface{}{"after", now, "next", now.Add(23 * time.Hour)}},
+	}
+	if !reflect.DeepEqual(rec.records, want) {
+		t.Errorf("Timer.NextAfter() logged %v, want %v", rec.records, want)
+	}
+
+	// nothing is built for the levels which are not enabled.
+	rec = &recorder{level: LevelInfo}
+	if _, err := tr.SetLogger(rec).NextAfter(now); err != nil || len(rec.records) != 0 {
+		t.Errorf("Timer.NextAfter() logged %v, want nothing", rec.records)
+	}
+	if ByTimestamp(true).dur.tracing() {
+		t.Errorf("duration.tracing() = true, want false without logger")
+	}
+}
+
+func TestInterval_SetLogger(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC))
+	rec := &recorder{level: LevelDebug}
+	if _, err := ByFreq(true).AddMinute(5).SetClock(c).SetLogger(rec).Next(); err != nil {
+		t.Fatalf("Interval.Next() error = %v", err)
+	}
+
+	want := []record{{LevelDebug, "run armed", []interface{}{"now", c.Now(), "interval", 5 * time.Minute}}}
+	if !reflect.DeepEqual(rec.records, want) {
+		t.Errorf("Interval.Next() logged %v, want %v", rec.records, want)
+	}
+}
+
+func TestTimer_UnmarshalJSON_logger(t *testing.T) {
+	rec := &recorder{level: LevelDebug}
+	tr := ByTimestamp(true).SetLogger(rec)
+	if err := tr.UnmarshalJSON([]byte(`{"repeat":true,"hours":[9]}`)); err != nil {
+		t.Fatalf("Timer.UnmarshalJSON() error = %v", err)
+	}
+	if tr.dur.logger != rec {
+		t.Errorf("Timer.UnmarshalJSON() dropped the logger")
+	}
+}
@@ -0,0 +1,652 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleData is the persisted form of a Timer or an Interval, in JSON and in text.
+// The clock, the context, the logger and the source of the jitter are not persisted, nor is the next run armed with Next.
+type scheduleData struct {
+	Repeat        bool     `json:"repeat"`
+	EveryN        int      `json:"everyN,omitempty"`
+	EveryFrom     string   `json:"everyFrom,omitempty"`
+	Start         string   `json:"start,omitempty"`
+	End           string   `json:"end,omitempty"`
+	MaxRuns       int      `json:"maxRuns,omitempty"`
+	Location      string   `json:"location,omitempty"`
+	Year          int      `json:"year,omitempty"`
+	Month         int      `json:"month,omitempty"`
+	Week          int      `json:"week,omitempty"`
+	Day           int      `json:"day,omitempty"`
+	Date          int      `json:"date,omitempty"`
+	Hour          int      `json:"hour,omitempty"`
+	Minute        int      `json:"minute,omitempty"`
+	Second        int      `json:"second,omitempty"`
+	Nsec          int      `json:"nsec,omitempty"`
+	Years         []int    `json:"years,omitempty"`
+	Months        []int    `json:"months,omitempty"`
+	Days          []int    `json:"days,omitempty"`
+	Dates         []int    `json:"dates,omitempty"`
+	Hours         []int    `json:"hours,omitempty"`
+	Minutes       []int    `json:"minutes,omitempty"`
+	Seconds       []int    `json:"seconds,omitempty"`
+	DayOrDate     bool     `json:"dayOrDate,omitempty"`
+	LastDate      bool     `json:"lastDate,omitempty"`
+	NearestDates  []int    `json:"nearestDates,omitempty"`
+	LastDays      []int    `json:"lastDays,omitempty"`
+	NthDays       []string `json:"nthDays,omitempty"`
+	HashKey       string   `json:"hashKey,omitempty"`
+	Hashes        []string `json:"hashes,omitempty"`
+	Gap           string   `json:"gap,omitempty"`
+	Overlap       string   `json:"overlap,omitempty"`
+	Anchor        string   `json:"anchor,omitempty"`
+	Mode          string   `json:"mode,omitempty"`
+	Missed        string   `json:"missed,omitempty"`
+	Jitter        string   `json:"jitter,omitempty"`
+	JitterPercent float64  `json:"jitterPercent,omitempty"`
+	Calendar      string   `json:"calendar,omitempty"`
+	Blackouts     []string `json:"blackouts,omitempty"`
+}
+
+// setUnits are the time units whose values are persisted in the given fields of scheduleData.
+var setUnits = []timeUnit{year, month, day, date, hour, minute, second}
+
+// sets returns the fields of scheduleData holding the values of the time units, in the order of setUnits.
+func (d *scheduleData) sets() []*[]int {
+	return []*[]int{&d.Years, &d.Months, &d.Days, &d.Dates, &d.Hours, &d.Minutes, &d.Seconds}
+}
+
+// MarshalJSON encodes every setting of the scheduler, its location by IANA name.
+// The clock, the context, the logger and the source of the jitter are left out, as is the next run armed with Next.
+//
+// eg:
+//	...
+//	b, err := json.Marshal(t)   // will return eg: {"repeat":true,"location":"Europe/Berlin","hours":[9,17],...}
+//	...
+func (t Timer) MarshalJSON() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON decodes the settings of the scheduler encoded with MarshalJSON.
+// The clock, the context and the logger of the scheduler are kept, the system clock being used when none is set.
+func (t *Timer) UnmarshalJSON(b []byte) error {
+	var d scheduleData
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+	return t.load(&d, true)
+}
+
+// MarshalText encodes every setting of the scheduler as semicolon separated key=value pairs, see MarshalJSON.
+//
+// eg:
+//	...
+//	b, err := t.MarshalText()   // will return eg: repeat=true;location=Europe/Berlin;hours=9,17;minutes=0;seconds=0
+//	...
+func (t Timer) MarshalText() ([]byte, error) {
+	d, err := t.data()
+	if err != nil {
+		return nil, err
+	}
+	return d.text(), nil
+}
+
+// UnmarshalText decodes the settings of the scheduler encoded with MarshalText.
+func (t *Timer) UnmarshalText(b []byte) error {
+	var d scheduleData
+	if err := d.parse(string(b)); err != nil {
+		return err
+	}
+	return t.load(&d, true)
+}
+
+// MarshalJSON encodes every setting of the scheduler, its location by IANA name.
+// The clock, the context, the logger and the source of the jitter are left out, as is the next run prepared with Next.
+//
+// eg:
+//	...
+//	b, err := json.Marshal(i)   // will return eg: {"repeat":true,"location":"UTC","hour":1,"anchor":"2020-01-01T00:30:00Z"}
+//	...
+func (i Interval) MarshalJSON() ([]byte, error) {
+	d, err := i.data()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
+
+// UnmarshalJSON decodes the settings of the scheduler encoded with MarshalJSON.
+// The clock, the context and the logger of the scheduler are kept, the system clock being used when none is set.
+func (i *Interval) UnmarshalJSON(b []byte) error {
+	var d scheduleData
+	if err := json.Unmarshal(b, &d); err != nil {
+		return err
+	}
+	return i.load(&d, false)
+}
+
+// MarshalText encodes every setting of the scheduler as semicolon separated key=value pairs, see MarshalJSON.
+//
+// eg:
+//	...
+//	b, err := i.MarshalText()   // will return eg: repeat=true;location=UTC;hour=1;anchor=2020-01-01T00:30:00Z
+//	...
+func (i Interval) MarshalText() ([]byte, error) {
+	d, err := i.data()
+	if err != nil {
+		return nil, err
+	}
+	return d.text(), nil
+}
+
+// UnmarshalText decodes the settings of the scheduler encoded with MarshalText.
+func (i *Interval) UnmarshalText(b []byte) error {
+	var d scheduleData
+	if err := d.parse(string(b)); err != nil {
+		return err
+	}
+	return i.load(&d, false)
+}
+
+// data returns the persisted form of the settings of the scheduler.
+// It fails when the scheduler is not set up or invalid, or when its location has no IANA name to be loaded back from.
+func (s *schedule) data() (*scheduleData, error) {
+	if s.dur == nil {
+		return nil, fmt.Errorf("%w: the scheduler is not set up, see ByTimestamp and ByFreq", ErrInvalid)
+	}
+	if s.dur.err != nil {
+		return nil, s.dur.err
+	}
+	dur := s.dur
+	d := &scheduleData{
+		Repeat:        s.repeat,
+		EveryN:        s.schedEveryN,
+		Year:          dur.Year,
+		Month:         dur.Month,
+		Week:          dur.Week,
+		Day:           dur.Day,
+		Date:          dur.date,
+		Hour:          dur.Hour,
+		Minute:        dur.Minute,
+		Second:        dur.Second,
+		Nsec:          dur.Nsec,
+		DayOrDate:     dur.dayOrDate,
+		LastDate:      dur.lastDate,
+		NearestDates:  dur.nearestDates,
+		LastDays:      dur.lastDays,
+		HashKey:       dur.hashKey,
+		MaxRuns:       s.maxRuns,
+		JitterPercent: s.jitter.pct,
+	}
+
+	var err error
+	if d.Location, err = locationName(dur.location); err != nil {
+		return nil, err
+	}
+	for i, units := range setUnits {
+		// the values of the hashed time units are derived from their range, which is persisted instead.
+		if _, ok := dur.hashes[units]; !ok {
+			*d.sets()[i] = dur.sets[units]
+		}
+	}
+	for _, nd := range dur.nthDays {
+		d.NthDays = append(d.NthDays, fmt.Sprintf("%d#%d", nd[0], nd[1]))
+	}
+	for units, r := range dur.hashes {
+		d.Hashes = append(d.Hashes, fmt.Sprintf("%s:%d-%d/%d", units, r.from, r.to, r.step))
+	}
+	sort.Strings(d.Hashes)
+	if dur.gap != GapShiftForward {
+		d.Gap = dur.gap.String()
+	}
+	if dur.overlap != OverlapFireOnce {
+		d.Overlap = dur.overlap.String()
+	}
+	if !s.everyFrom.IsZero() {
+		if d.EveryFrom, err = formatTime(s.everyFrom); err != nil {
+			return nil, err
+		}
+	}
+	if !s.start.IsZero() {
+		if d.Start, err = formatTime(s.start); err != nil {
+			return nil, err
+		}
+	}
+	if !s.end.IsZero() {
+		if d.End, err = formatTime(s.end); err != nil {
+			return nil, err
+		}
+	}
+	if !s.anchor.IsZero() {
+		if d.Anchor, err = formatTime(s.anchor); err != nil {
+			return nil, err
+		}
+	}
+	if s.mode != FixedDelay {
+		d.Mode = s.mode.String()
+	}
+	if s.missed != MissedSkip {
+		d.Missed = s.missed.String()
+	}
+	if s.jitter.max > 0 {
+		d.Jitter = s.jitter.max.String()
+	}
+	if c := s.calendar; c != nil {
+		if c.err != nil {
+			return nil, c.err
+		}
+		if d.Calendar, err = locationName(c.location); err != nil {
+			return nil, err
+		}
+		for _, b := range c.ranges {
+			d.Blackouts = append(d.Blackouts, b.start.UTC().Format(time.RFC3339Nano)+"/"+b.end.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	return d, nil
+}
+
+// locationName returns the IANA name of the location, which it can be loaded back from.
+func locationName(loc *time.Location) (string, error) {
+	name := loc.String()
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("location %q has no IANA time zone name", name)
+	}
+	return name, nil
+}
+
+// formatTime returns the time in RFC 3339, followed by the IANA name of its location in brackets
+// unless it is UTC, eg: 2020-01-01T09:00:00+01:00[Europe/Berlin]
+func formatTime(t time.Time) (string, error) {
+	if t.Location() == time.UTC {
+		return t.Format(time.RFC3339Nano), nil
+	}
+	name, err := locationName(t.Location())
+	if err != nil {
+		return "", err
+	}
+	return t.Format(time.RFC3339Nano) + "[" + name + "]", nil
+}
+
+// parseTime parses a time written by formatTime.
+func parseTime(s string) (time.Time, error) {
+	s, name, _ := strings.Cut(strings.TrimSuffix(s, "]"), "[")
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || name == "" {
+		return t, err
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return t, err
+	}
+	return t.In(loc), nil
+}
+
+// load replaces the settings of the scheduler with the persisted ones, keeping its clock, context and logger.
+// The settings which only apply to a Timer are rejected when timer is false.
+func (s *schedule) load(d *scheduleData, timer bool) error {
+	if !timer {
+		if name := d.timerOnly(); name != "" {
+			return fmt.Errorf("%s is not a setting of an Interval", name)
+		}
+	}
+
+	ctx, clock := s.context, s.clock
+	if ctx == nil {
+		ctx = setCtx(nil)
+	}
+	if clock == nil {
+		clock = systemClock
+	}
+	var logger Logger
+	if s.dur != nil {
+		logger = s.dur.logger
+	}
+	n := newSched(d.Repeat, ctx, clock)
+	if d.EveryN != 0 {
+		if n.everyNth(d.EveryN); n.dur.err != nil {
+			return n.dur.err
+		}
+	}
+	*n.dur = duration{
+		Year:         d.Year,
+		Month:        d.Month,
+		Week:         d.Week,
+		Day:          d.Day,
+		date:         d.Date,
+		Hour:         d.Hour,
+		Minute:       d.Minute,
+		Second:       d.Second,
+		Nsec:         d.Nsec,
+		location:     time.UTC,
+		dayOrDate:    d.DayOrDate,
+		lastDate:     d.LastDate,
+		nearestDates: d.NearestDates,
+		lastDays:     d.LastDays,
+		hashKey:      d.HashKey,
+		logger:       logger,
+	}
+
+	var err error
+	if d.Location != "" {
+		if n.dur.location, err = time.LoadLocation(d.Location); err != nil {
+			return fmt.Errorf("unknown location %q", d.Location)
+		}
+	}
+	for i, units := range setUnits {
+		if vals := *d.sets()[i]; vals != nil {
+			if n.dur.sets == nil {
+				n.dur.sets = map[timeUnit][]int{}
+			}
+			n.dur.sets[units] = vals
+		}
+	}
+	for _, day := range d.NthDays {
+		var nd [2]int
+		if _, err := fmt.Sscanf(day, "%d#%d", &nd[0], &nd[1]); err != nil {
+			return fmt.Errorf("invalid nth day %q", day)
+		}
+		n.dur.nthDays = append(n.dur.nthDays, nd)
+	}
+	for _, h := range d.Hashes {
+		units, r, err := parseHashRange(h)
+		if err != nil {
+			return err
+		}
+		if n.dur.hashes == nil {
+			n.dur.hashes = map[timeUnit]hashRange{}
+		}
+		n.dur.hashes[units] = r
+	}
+	if n.dur.gap, err = parseGapPolicy(d.Gap); err != nil {
+		return err
+	}
+	if n.dur.overlap, err = parseOverlapPolicy(d.Overlap); err != nil {
+		return err
+	}
+	if d.EveryFrom != "" {
+		if n.everyFrom, err = parseTime(d.EveryFrom); err != nil {
+			return fmt.Errorf("invalid everyFrom %q", d.EveryFrom)
+		}
+	}
+	for _, b := range []struct {
+		name string
+		text string
+		set  func(time.Time)
+	}{
+		{"start", d.Start, n.setStart}, {"end", d.End, n.setEnd},
+	} {
+		if b.text == "" {
+			continue
+		}
+		t, err := parseTime(b.text)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", b.name, b.text)
+		}
+		b.set(t)
+	}
+	if d.MaxRuns != 0 {
+		n.setMaxRuns(d.MaxRuns)
+	}
+	if n.dur.err != nil {
+		return n.dur.err
+	}
+	if d.Anchor != "" {
+		if n.anchor, err = parseTime(d.Anchor); err != nil {
+			return fmt.Errorf("invalid anchor %q", d.Anchor)
+		}
+	}
+	if n.mode, err = parseIntervalMode(d.Mode); err != nil {
+		return err
+	}
+	if n.missed, err = parseMissedPolicy(d.Missed); err != nil {
+		return err
+	}
+	var max time.Duration
+	if d.Jitter != "" {
+		if max, err = time.ParseDuration(d.Jitter); err != nil {
+			return fmt.Errorf("invalid jitter %q", d.Jitter)
+		}
+	}
+	n.setJitter(max, d.JitterPercent)
+	if d.Calendar != "" || len(d.Blackouts) > 0 {
+		if n.calendar, err = loadCalendar(d.Calendar, d.Blackouts); err != nil {
+			return err
+		}
+	}
+	if n.dur.err != nil {
+		return n.dur.err
+	}
+	*s = n
+	return nil
+}
+
+// timerOnly returns the name of the first setting which only applies to a Timer, if any.
+func (d *scheduleData) timerOnly() string {
+	v := reflect.ValueOf(d).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(v.Type().Field(i))
+		switch name {
+		case "repeat", "everyN", "start", "end", "maxRuns", "location", "year", "month", "week", "day", "date", "hour", "minute", "second", "nsec",
+			"anchor", "mode", "missed", "jitter", "jitterPercent", "calendar", "blackouts":
+			continue
+		}
+		if !v.Field(i).IsZero() {
+			return name
+		}
+	}
+	return ""
+}
+
+// parseHashRange parses a hashed time unit persisted as unit:from-to/step, eg: minute:0-59/15
+func parseHashRange(s string) (timeUnit, hashRange, error) {
+	name, rest, _ := strings.Cut(s, ":")
+	var r hashRange
+	if _, err := fmt.Sscanf(rest, "%d-%d/%d", &r.from, &r.to, &r.step); err != nil {
+		return 0, r, fmt.Errorf("invalid hashed range %q", s)
+	}
+	for _, units := range setUnits {
+		if units.String() == name {
+			return units, r, nil
+		}
+	}
+	return 0, r, fmt.Errorf("invalid hashed range %q", s)
+}
+
+// parseGapPolicy returns the gap policy of the given name, the default one when empty.
+func parseGapPolicy(name string) (GapPolicy, error) {
+	for _, p := range []GapPolicy{GapShiftForward, GapSkip} {
+		if name == "" || name == p.String() {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gap policy %q", name)
+}
+
+// parseOverlapPolicy returns the overlap policy of the given name, the default one when empty.
+func parseOverlapPolicy(name string) (OverlapPolicy, error) {
+	for _, p := range []OverlapPolicy{OverlapFireOnce, OverlapFireTwice} {
+		if name == "" || name == p.String() {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown overlap policy %q", name)
+}
+
+// parseIntervalMode returns the interval mode of the given name, the default one when empty.
+func parseIntervalMode(name string) (IntervalMode, error) {
+	for _, m := range []IntervalMode{FixedDelay, FixedRate} {
+		if name == "" || name == m.String() {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown interval mode %q", name)
+}
+
+// parseMissedPolicy returns the missed policy of the given name, the default one when empty.
+func parseMissedPolicy(name string) (MissedPolicy, error) {
+	for _, p := range []MissedPolicy{MissedSkip, MissedFireOnce, MissedFireAll} {
+		if name == "" || name == p.String() {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown missed policy %q", name)
+}
+
+// loadCalendar returns the calendar of the given location, excluding the ranges persisted as start/end.
+func loadCalendar(location string, blackouts []string) (*Calendar, error) {
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unknown calendar location %q", location)
+	}
+	c := NewCalendar(loc)
+	for _, b := range blackouts {
+		from, to, _ := strings.Cut(b, "/")
+		start, err1 := time.Parse(time.RFC3339Nano, from)
+		end, err2 := time.Parse(time.RFC3339Nano, to)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid blackout %q", b)
+		}
+		c.ExcludeRange(start, end)
+	}
+	return c, c.err
+}
+
+// jsonName returns the name of the field in JSON, which is also its key in text.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// text returns the settings as semicolon separated key=value pairs, leaving out the zero ones but repeat.
+// Lists are comma separated, and the strings holding separators are quoted.
+func (d *scheduleData) text() []byte {
+	var pairs []string
+	v := reflect.ValueOf(d).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		name := jsonName(v.Type().Field(i))
+		if (f.IsZero() || f.Kind() == reflect.Slice && f.Len() == 0) && name != "repeat" {
+			continue
+		}
+
+		var val string
+		switch f.Kind() {
+		case reflect.Slice:
+			items := make([]string, f.Len())
+			for j := range items {
+				items[j] = textValue(f.Index(j))
+			}
+			val = strings.Join(items, ",")
+		default:
+			val = textValue(f)
+		}
+		pairs = append(pairs, name+"="+val)
+	}
+	return []byte(strings.Join(pairs, ";"))
+}
+
+// textValue returns the value in text, quoted when it is a string holding separators.
+func textValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	if s := v.String(); s == "" || strings.ContainsAny(s, `;,="\ `) {
+		return strconv.Quote(s)
+	}
+	return v.String()
+}
+
+// parse reads the settings from semicolon separated key=value pairs, as written by text.
+func (d *scheduleData) parse(text string) error {
+	fields := map[string]reflect.Value{}
+	v := reflect.ValueOf(d).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		fields[jsonName(v.Type().Field(i))] = v.Field(i)
+	}
+
+	for _, pair := range splitQuoted(text, ';') {
+		if pair == "" {
+			continue
+		}
+		name, val, ok := strings.Cut(pair, "=")
+		f, known := fields[name]
+		if !ok || !known {
+			return fmt.Errorf("invalid setting %q", pair)
+		}
+		if f.Kind() != reflect.Slice {
+			if err := setText(f, val); err != nil {
+				return fmt.Errorf("invalid setting %q", pair)
+			}
+			continue
+		}
+		items := splitQuoted(val, ',')
+		f.Set(reflect.MakeSlice(f.Type(), len(items), len(items)))
+		for j, item := range items {
+			if err := setText(f.Index(j), item); err != nil {
+				return fmt.Errorf("invalid setting %q", pair)
+			}
+		}
+	}
+	return nil
+}
+
+// setText sets the value from its text, as written by textValue.
+func setText(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		v.SetBool(b)
+		return err
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		v.SetInt(int64(n))
+		return err
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		v.SetFloat(f)
+		return err
+	}
+	if strings.HasPrefix(s, `"`) {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return err
+		}
+	}
+	v.SetString(s)
+	return nil
+}
+
+// splitQuoted splits the text around the separator, leaving the separators within quoted strings.
+func splitQuoted(text string, sep rune) []string {
+	var parts []string
+	quoted, escaped, start := false, false, 0
+	for i, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
@@ -0,0 +1,258 @@
+package schedule
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "time/tzdata"
+)
+
+// roundTrip marshals the scheduler and unmarshals it into out, in JSON or in text.
+func roundTrip(t *testing.T, in interface{}, out interface{}, text bool) []byte {
+	t.Helper()
+	var b []byte
+	var err error
+	if text {
+		b, err = in.(interface{ MarshalText() ([]byte, error) }).MarshalText()
+	} else {
+		b, err = json.Marshal(in)
+	}
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if text {
+		err = out.(interface{ UnmarshalText([]byte) error }).UnmarshalText(b)
+	} else {
+		err = json.Unmarshal(b, out)
+	}
+	if err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", b, err)
+	}
+	return b
+}
+
+func TestTimer_MarshalJSON(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	cal := NewCalendar(berlin).ExcludeDate(2020, December, 24).ExcludeDate(2020, December, 25)
+	tests := []struct {
+		name  string
+		timer Timer
+	}{
+		{
+			name:  "legacy values",
+			timer: ByTimestamp(false).SetYear(2030).SetMonth(March).SetDate(2).SetHour(13).SetMinute(4).SetSecond(5).SetNanosecond(6),
+		}, {
+			name: "every setting",
+			timer: ByTimestamp(true).SetLocation(berlin).SetHours(9, 17).SetMinutes(0, 30).SetSecondHash(0, 59).SetHashKey("backup; nightly").
+				SetDays(Monday, Wednesday).SetNthDay(Tuesday, 2).SetLastDay(Friday).SetLastDate().SetNearestWeekday(15).SetDayOrDate(true).
+				SetGapPolicy(GapSkip).SetOverlapPolicy(OverlapFireTwice).SetJitter(time.Minute).SetCalendar(cal),
+		}, {
+			name:  "jitter percentage",
+			timer: ByTimestamp(true).SetMonthStep(3).SetDates(1).SetJitterPercent(2.5),
+		},
+	}
+	for _, tt := range tests {
+		for _, text := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.timer.schedEveryN = 3
+				var got Timer
+				b := roundTrip(t, tt.timer, &got, text)
+
+				// the values of the hashed time units are resolved once scheduled, as the timer of the previous run was.
+				got.dur.resolveHashes()
+				tt.timer.dur.resolveHashes()
+				if !reflect.DeepEqual(got.dur, tt.timer.dur) {
+					t.Errorf("Unmarshal(%s) = %+v, want %+v", b, got.dur, tt.timer.dur)
+				}
+				if got.repeat != tt.timer.repeat || got.schedEveryN != 3 || got.jitter.max != tt.timer.jitter.max || got.jitter.pct != tt.timer.jitter.pct {
+					t.Errorf("Unmarshal(%s) = %+v, want %+v", b, got.schedule, tt.timer.schedule)
+				}
+				if (got.calendar == nil) != (tt.timer.calendar == nil) || got.calendar != nil && !sameRanges(got.calendar.ranges, cal.ranges) {
+					t.Errorf("Unmarshal(%s) calendar = %+v, want %+v", b, got.calendar, tt.timer.calendar)
+				}
+
+				from := time.Date(2020, time.December, 20, 0, 0, 0, 0, time.UTC)
+				want, wantErr := NextN(&tt.timer, from, 5)
+				if next, err := NextN(&got, from, 5); !reflect.DeepEqual(next, want) || (err == nil) != (wantErr == nil) {
+					t.Errorf("NextN() = %v, %v, want %v, %v", next, err, want, wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestTimer_MarshalText(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	tr := ByTimestamp(true).SetLocation(berlin).SetDays(Monday, Friday).SetHours(9).SetMinutes(0).SetSeconds(0).SetNthDay(Tuesday, 2).SetHashKey("a=b")
+
+	b, err := tr.MarshalText()
+	if err != nil {
+		t.Fatalf("Timer.MarshalText() error = %v", err)
+	}
+	want := `repeat=true;location=Europe/Berlin;days=1,5;hours=9;minutes=0;seconds=0;nthDays=2#2;hashKey="a=b"`
+	if string(b) != want {
+		t.Errorf("Timer.MarshalText() = %s, want %s", b, want)
+	}
+
+	b, err = json.Marshal(tr)
+	if err != nil {
+		t.Fatalf("Timer.MarshalJSON() error = %v", err)
+	}
+	want = `{"repeat":true,"location":"Europe/Berlin","days":[1,5],"hours":[9],"minutes":[0],"seconds":[0],"nthDays":["2#2"],"hashKey":"a=b"}`
+	if string(b) != want {
+		t.Errorf("Timer.MarshalJSON() = %s, want %s", b, want)
+	}
+}
+
+func TestTimer_MarshalJSON_everyNth(t *testing.T) {
+	start := time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC)
+	tr := ByTimestamp(true).SetDays(Friday).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(3, start)
+	for _, text := range []bool{false, true} {
+		var got Timer
+		b := roundTrip(t, tr, &got, text)
+		if got.schedEveryN != 3 || !got.everyFrom.Equal(start) {
+			t.Errorf("Unmarshal(%s) = every %d from %v, want every 3 from %v", b, got.schedEveryN, got.everyFrom, start)
+		}
+		want, _ := NextN(&tr, start, 3)
+		if next, err := NextN(&got, start.AddDate(0, 0, 10), 2); err != nil || !reflect.DeepEqual(next, want[1:]) {
+			t.Errorf("NextN() = %v, %v, want %v", next, err, want[1:])
+		}
+	}
+}
+
+func TestInterval_MarshalJSON_bounds(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC)
+	i := ByFreq(true).AddHour(2).StartAt(start).EndAt(start.AddDate(0, 0, 1)).MaxRuns(3)
+	for _, text := range []bool{false, true} {
+		var got Interval
+		b := roundTrip(t, i, &got, text)
+		if !got.start.Equal(i.start) || !got.end.Equal(i.end) || got.maxRuns != 3 || !got.anchor.Equal(start) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", b, got.schedule, i.schedule)
+		}
+	}
+}
+
+func TestInterval_MarshalJSON_mode(t *testing.T) {
+	i := ByFreq(true).AddMinute(5).SetMode(FixedRate).SetMissedPolicy(MissedFireAll)
+	for _, text := range []bool{false, true} {
+		var got Interval
+		b := roundTrip(t, i, &got, text)
+		if got.mode != FixedRate || got.missed != MissedFireAll {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", b, got.schedule, i.schedule)
+		}
+	}
+
+	b, _ := i.MarshalText()
+	if want := "repeat=true;location=UTC;minute=5;mode=fixed-rate;missed=fire-all"; string(b) != want {
+		t.Errorf("Interval.MarshalText() = %s, want %s", b, want)
+	}
+}
+
+func TestInterval_MarshalJSON(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	anchor := time.Date(2020, time.March, 28, 9, 0, 0, 0, berlin)
+	freeze := NewCalendar(nil).ExcludeRange(anchor.AddDate(0, 0, 2), anchor.AddDate(0, 0, 3))
+	i := ByFreq(true).AddDay(1).AddHour(1).AddNsec(7).SetAnchor(anchor).SetJitterPercent(10).SetCalendar(freeze)
+
+	for _, text := range []bool{false, true} {
+		var got Interval
+		b := roundTrip(t, i, &got, text)
+		if !reflect.DeepEqual(got.dur, i.dur) || !got.anchor.Equal(anchor) || got.anchor.Location().String() != "Europe/Berlin" || got.jitter.pct != 10 {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", b, got.schedule, i.schedule)
+		}
+
+		// the days of the anchor are counted in its location, across the daylight saving transition.
+		want, _ := NextN(&i, anchor, 3)
+		if next, err := NextN(&got, anchor, 3); err != nil || !reflect.DeepEqual(next, want) {
+			t.Errorf("NextN() = %v, %v, want %v", next, err, want)
+		}
+	}
+
+	b, _ := i.SetJitterPercent(0).SetCalendar(nil).MarshalText()
+	if want := "repeat=true;location=UTC;day=1;hour=1;nsec=7;anchor=2020-03-28T09:00:00+01:00[Europe/Berlin]"; string(b) != want {
+		t.Errorf("Interval.MarshalText() = %s, want %s", b, want)
+	}
+}
+
+func TestMarshal_errors(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name      string
+		marshal   func() ([]byte, error)
+		unmarshal func() error
+	}{
+		{
+			name:    "invalid timer",
+			marshal: func() ([]byte, error) { return json.Marshal(ByTimestamp(true).SetHourStep(0)) },
+		}, {
+			name:    "timer not set up",
+			marshal: func() ([]byte, error) { return json.Marshal(Timer{}) },
+		}, {
+			name:    "interval not set up within a struct",
+			marshal: func() ([]byte, error) { return json.Marshal(struct{ Every Interval }{}) },
+		}, {
+			name:    "location without IANA name",
+			marshal: ByTimestamp(true).SetLocation(time.FixedZone("UTC+5", 5*3600)).MarshalText,
+		}, {
+			name:    "invalid calendar",
+			marshal: ByFreq(true).AddHour(1).SetCalendar(NewCalendar(nil).ExcludeDate(2021, February, 29)).MarshalText,
+		}, {
+			name:      "unknown location",
+			unmarshal: func() error { return json.Unmarshal([]byte(`{"location":"Mars/Olympus"}`), ByTimestamp(true)) },
+		}, {
+			name:      "timer setting of an interval",
+			unmarshal: func() error { return json.Unmarshal([]byte(`{"hours":[9]}`), ByFreq(true)) },
+		}, {
+			name:      "unknown setting",
+			unmarshal: func() error { return ByTimestamp(true).UnmarshalText([]byte("repeat=true;moon=full")) },
+		}, {
+			name:      "invalid value",
+			unmarshal: func() error { return ByTimestamp(true).UnmarshalText([]byte("hours=9,x")) },
+		}, {
+			name:      "invalid policy",
+			unmarshal: func() error { return ByTimestamp(true).UnmarshalText([]byte("gap=sideways")) },
+		}, {
+			name:      "invalid interval mode",
+			unmarshal: func() error { return ByFreq(true).UnmarshalText([]byte("minute=5;mode=sometimes")) },
+		}, {
+			name:      "invalid missed policy",
+			unmarshal: func() error { return ByFreq(true).UnmarshalText([]byte("minute=5;missed=later")) },
+		}, {
+			name: "invalid jitter",
+			unmarshal: func() error {
+				i := ByFreq(true).SetClock(c)
+				return i.UnmarshalText([]byte("hour=1;jitterPercent=150"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.marshal != nil {
+				_, err = tt.marshal()
+			} else {
+				err = tt.unmarshal()
+			}
+			if err == nil {
+				t.Errorf("error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestTimer_UnmarshalJSON_clock(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	tr := ByTimestamp(true).SetClock(c)
+	if err := json.Unmarshal([]byte(`{"repeat":true,"minutes":[30],"seconds":[0]}`), &tr); err != nil {
+		t.Fatalf("Timer.UnmarshalJSON() error = %v", err)
+	}
+	s, err := tr.Next()
+	if err != nil {
+		t.Fatalf("Timer.Next() error = %v", err)
+	}
+	if got, want := s.(*Timer).timer, time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Timer.Next() = %v, want %v", got, want)
+	}
+}
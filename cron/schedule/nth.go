@@ -0,0 +1,177 @@
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// runCount remembers how many runs of a Timer were counted from the start of its count,
+// so the runs of a scheduler running every Nth time are not counted again from the start on each run.
+// It is shared by the copies of the scheduler.
+type runCount struct {
+	mu    sync.Mutex
+	from  time.Time // start of the count.
+	at    time.Time // last run counted, zero when none is.
+	index int       // number of runs from the first one to at.
+}
+
+// everyNth sets the scheduler to run only on every nth of its runs.
+func (s *schedule) everyNth(n int) {
+	if n < 1 {
+		s.invalid("every nth run must be at least 1, given %d", n)
+		return
+	}
+	s.schedEveryN = n
+	s.runs = &runCount{}
+}
+
+// nth returns how many of its runs the scheduler skips from one run to the next, 1 when it runs every time.
+func (s *schedule) nth() int {
+	if s.schedEveryN < 1 {
+		return 1
+	}
+	return s.schedEveryN
+}
+
+// nthRun returns the nth run from t, step returning the run following the given time, either way.
+func nthRun(n int, t time.Time, step func(time.Time) (time.Time, error)) (at time.Time, err error) {
+	at = t
+	for ; n > 0 && err == nil; n-- {
+		at, err = step(at)
+	}
+	return at, err
+}
+
+// maxCountedRuns is the number of runs a Timer counts one at a time from the start of its count before giving up,
+// the runs which repeat at a fixed period being counted by whole periods instead.
+const maxCountedRuns = 100000
+
+// first returns the first run of the duration after t, along with the number of runs from the start of the count to it.
+// The runs are counted by whole periods while they repeat at a fixed period, see duration.period,
+// and one at a time otherwise, eg: across the daylight saving transitions.
+func (c *runCount) first(from, t time.Time, d *duration) (time.Time, int, error) {
+	if c == nil {
+		c = &runCount{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the count starts over when t is before the last run counted.
+	at, index := c.at, c.index
+	if at.IsZero() || !c.from.Equal(from) || t.Before(at) {
+		var err error
+		if at, err = d.nextDate(from.Add(-time.Nanosecond)); err != nil {
+			return time.Time{}, 0, err
+		}
+		index = 0
+		c.from, c.at, c.index = from, time.Time{}, 0
+	}
+	p, perPeriod := d.period()
+	var change time.Time // next change of the offset of the location after at, zero when none is up to t.
+	for counted := 0; !at.After(t); counted++ {
+		// jump over the whole periods up to t, or up to the next change of the offset of the location.
+		if p > 0 {
+			if change.IsZero() || !at.Before(change) {
+				change = offsetChange(at, t, d.location)
+			}
+			end := t
+			if !change.IsZero() {
+				end = change.Add(-time.Nanosecond)
+			}
+			if k := int(end.Sub(at) / p); k > 0 {
+				at, index = at.Add(time.Duration(k)*p), index+k*perPeriod
+			}
+		}
+		if counted == maxCountedRuns {
+			return time.Time{}, 0, fmt.Errorf("%w within %d runs counted from %s, move the start of the count closer", ErrNoMatch, maxCountedRuns, from.Format(time.RFC3339))
+		}
+
+		c.at, c.index = at, index
+		next, err := d.nextDate(at)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		at, index = next, index+1
+	}
+	return at, index, nil
+}
+
+// next returns the first run after t which is an nth run from the start of the count.
+func (c *runCount) next(n int, from, t time.Time, d *duration) (time.Time, error) {
+	at, index, err := c.first(from, t, d)
+	for ; err == nil && index%n != 0; index++ {
+		at, err = d.nextDate(at)
+	}
+	return at, err
+}
+
+// prev returns the last run before t which is an nth run from the start of the count.
+func (c *runCount) prev(n int, from, t time.Time, d *duration) (time.Time, error) {
+	at, index, err := c.first(from, t.Add(-time.Nanosecond), d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if index == 0 {
+		return time.Time{}, fmt.Errorf("%w before %s, the runs are counted from %s", ErrNoMatch, t.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+
+	// step back from the first run at or after t to the last nth run before it.
+	for steps := index - (index-1)/n*n; steps > 0; steps-- {
+		if at, err = d.prevDate(at); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return at, nil
+}
+
+// period returns the fixed period the runs of the duration repeat at while the offset of its location does not change,
+// along with the number of runs in a period: a week, a day, an hour or a minute when the weekdays, the hours, the minutes
+// or the seconds are the largest time units set. The period is zero when the runs depend on the dates, eg: when the months are set.
+func (d *duration) period() (p time.Duration, perPeriod int) {
+	d, err := d.validated()
+	if err != nil || d.lastDate || len(d.nearestDates) > 0 || len(d.lastDays) > 0 || len(d.nthDays) > 0 {
+		return 0, 0
+	}
+	for _, units := range []timeUnit{year, month, date} {
+		if d.values(units) != nil {
+			return 0, 0
+		}
+	}
+
+	// the runs in a period are the combinations of the values of the time units, up to the largest one set.
+	runs := 1
+	for _, u := range []struct {
+		units  timeUnit
+		period time.Duration
+	}{
+		{second, time.Minute}, {minute, time.Hour}, {hour, 24 * time.Hour}, {day, 7 * 24 * time.Hour},
+	} {
+		vals := d.values(u.units)
+		if vals == nil {
+			lim := limits[u.units]
+			runs *= lim[1] - lim[0] + 1
+			continue
+		}
+		runs *= len(vals)
+		p, perPeriod = u.period, runs
+	}
+	return p, perPeriod
+}
+
+// offsetChange returns the first instant after from, up to to, at which the offset of the location changes,
+// zero when it does not. The offsets are compared a day apart, which no two transitions of a time zone are closer than.
+func offsetChange(from, to time.Time, loc *time.Location) time.Time {
+	_, off := from.In(loc).Zone()
+	for lo := from; lo.Before(to); {
+		hi := lo.Add(24 * time.Hour)
+		if hi.After(to) {
+			hi = to
+		}
+		if _, o := hi.In(loc).Zone(); o != off {
+			return transition(lo, hi.In(loc))
+		}
+		lo = hi
+	}
+	return time.Time{}
+}
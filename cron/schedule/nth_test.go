@@ -0,0 +1,267 @@
+package schedule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimer_EveryNth(t *testing.T) {
+	start := time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC) // a Friday
+	fridays := func() Timer {
+		return ByTimestamp(true).SetDays(Friday).SetHours(9).SetMinutes(0).SetSeconds(0)
+	}
+	at := func(m time.Month, d int) time.Time { return time.Date(2020, m, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		timer   Timer
+		after   time.Time
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name:  "every third Friday",
+			timer: fridays().EveryNth(3, start),
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  []time.Time{at(time.January, 3), at(time.January, 24), at(time.February, 14)},
+		}, {
+			name:  "restarted within the count",
+			timer: fridays().EveryNth(3, start),
+			after: time.Date(2020, time.January, 10, 12, 0, 0, 0, time.UTC),
+			want:  []time.Time{at(time.January, 24), at(time.February, 14), at(time.March, 6)},
+		}, {
+			name:  "counted from the first match after the start",
+			timer: fridays().EveryNth(2, start.AddDate(0, 0, 1)),
+			after: start,
+			want:  []time.Time{at(time.January, 10), at(time.January, 24), at(time.February, 7)},
+		}, {
+			name:  "excluded by the calendar",
+			timer: fridays().EveryNth(3, start).SetCalendar(NewCalendar(time.UTC).ExcludeDate(2020, January, 24)),
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  []time.Time{at(time.January, 3), at(time.February, 14), at(time.March, 6)},
+		}, {
+			name:  "not counted",
+			timer: ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(2),
+			after: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  []time.Time{at(time.January, 2), at(time.January, 4), at(time.January, 6)},
+		}, {
+			name:  "every run",
+			timer: fridays().EveryNth(1, start),
+			after: start,
+			want:  []time.Time{at(time.January, 3), at(time.January, 10), at(time.January, 17)},
+		}, {
+			name:    "invalid",
+			timer:   fridays().EveryNth(0),
+			after:   start,
+			want:    make([]time.Time, 1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextN(&tt.timer, tt.after, len(tt.want))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NextN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NextN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_EveryNth_Prev(t *testing.T) {
+	start := time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC)
+	fridays := ByTimestamp(true).SetDays(Friday).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(3, start)
+	daily := ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(2)
+
+	tests := []struct {
+		name    string
+		timer   Timer
+		before  time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "counted",
+			timer:  fridays,
+			before: time.Date(2020, time.February, 14, 9, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 24, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:   "counted after a later run",
+			timer:  fridays,
+			before: time.Date(2020, time.January, 24, 10, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 24, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:   "not counted",
+			timer:  daily,
+			before: time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 8, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:    "before the first run counted",
+			timer:   fridays,
+			before:  time.Date(2020, time.January, 3, 9, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.timer.Prev(tt.before)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Timer.Prev() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Timer.Prev() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterval_EveryNth(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 30, 0, 0, time.UTC)
+	from := time.Date(2020, time.January, 1, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval Interval
+		after    time.Time
+		want     time.Time
+		wantPrev time.Time
+	}{
+		{
+			name:     "anchored by the count",
+			interval: ByFreq(true).AddHour(1).EveryNth(3, start),
+			after:    from,
+			want:     start.Add(3 * time.Hour),
+			wantPrev: start,
+		}, {
+			name:     "on a counted tick",
+			interval: ByFreq(true).AddHour(1).EveryNth(3, start),
+			after:    start.Add(3 * time.Hour),
+			want:     start.Add(6 * time.Hour),
+			wantPrev: start,
+		}, {
+			name:     "anchored",
+			interval: ByFreq(true).AddMinute(20).SetAnchor(start).EveryNth(2),
+			after:    from,
+			want:     start.Add(40 * time.Minute),
+			wantPrev: start,
+		}, {
+			name:     "not anchored",
+			interval: ByFreq(true).AddMinute(10).EveryNth(3),
+			after:    from,
+			want:     from.Add(30 * time.Minute),
+			wantPrev: from.Add(-30 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.interval.NextAfter(tt.after)
+			if err != nil || !got.Equal(tt.want) {
+				t.Errorf("Interval.NextAfter() = %v, %v, want %v", got, err, tt.want)
+			}
+			prev, err := tt.interval.Prev(tt.after)
+			if err != nil || !prev.Equal(tt.wantPrev) {
+				t.Errorf("Interval.Prev() = %v, %v, want %v", prev, err, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func Test_runCount_restart(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tr := ByTimestamp(true).SetMinutes(0).SetSeconds(0).EveryNth(5, start)
+
+	// looking back after looking ahead starts the count over, with the same runs.
+	late, err := tr.NextAfter(start.Add(48 * time.Hour))
+	if err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+	early, err := tr.NextAfter(start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+	if want := start.Add(50 * time.Hour); !late.Equal(want) {
+		t.Errorf("Timer.NextAfter() = %v, want %v", late, want)
+	}
+	if want := start.Add(5 * time.Hour); !early.Equal(want) {
+		t.Errorf("Timer.NextAfter() = %v, want %v", early, want)
+	}
+}
+
+func Test_runCount_first(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	nyc, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2020, time.March, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		timer Timer
+		t     time.Time
+	}{
+		{
+			name:  "twice a minute",
+			timer: ByTimestamp(true).SetSeconds(0, 30).SetLocation(berlin),
+			t:     time.Date(2020, time.April, 2, 10, 15, 10, 0, time.UTC),
+		}, {
+			name:  "hours skipped by daylight saving",
+			timer: ByTimestamp(true).SetHours(1, 2, 3).SetMinutes(30).SetSeconds(0).SetLocation(nyc),
+			t:     time.Date(2020, time.November, 5, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "weekly",
+			timer: ByTimestamp(true).SetDays(Monday, Thursday).SetHours(9).SetMinutes(0).SetSeconds(0).SetLocation(berlin),
+			t:     time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "every second of a minute",
+			timer: ByTimestamp(true).SetMinutes(5).SetLocation(berlin),
+			t:     time.Date(2020, time.March, 30, 12, 5, 42, 0, time.UTC),
+		}, {
+			name:  "dates",
+			timer: ByTimestamp(true).SetDates(1, 15).SetHours(9).SetMinutes(0).SetSeconds(0),
+			t:     time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the runs counted one at a time.
+			want, _ := tt.timer.dur.nextDate(from.Add(-time.Nanosecond))
+			index := 0
+			for ; !want.After(tt.t); index++ {
+				want, _ = tt.timer.dur.nextDate(want)
+			}
+
+			var c runCount
+			got, n, err := c.first(from, tt.t, tt.timer.dur)
+			if err != nil || !got.Equal(want) || n != index {
+				t.Errorf("runCount.first() = %v, %d, %v, want %v, %d", got, n, err, want, index)
+			}
+		})
+	}
+}
+
+func Test_runCount_first_far(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2019, time.January, 1, 0, 0, 0, 0, berlin)
+	at := time.Date(2020, time.June, 1, 12, 0, 0, 0, berlin)
+
+	// the runs of a year and a half, every minute or every 10 seconds, are counted by whole periods.
+	// run on both occurrences of the repeated wall times, the runs are one minute apart across the transitions.
+	tr := ByTimestamp(true).SetSeconds(0).SetLocation(berlin).SetOverlapPolicy(OverlapFireTwice).EveryNth(7, from)
+	want := from.Add(time.Duration(int(at.Sub(from)/time.Minute)/7+1) * 7 * time.Minute)
+	if got, err := tr.NextAfter(at); err != nil || !got.Equal(want) {
+		t.Errorf("Timer.NextAfter() = %v, %v, want %v", got, err, want)
+	}
+	tr = ByTimestamp(true).SetSeconds(0, 10, 20, 30, 40, 50).SetLocation(berlin).EveryNth(60, from)
+	if _, err := tr.Prev(at); err != nil {
+		t.Errorf("Timer.Prev() error = %v", err)
+	}
+
+	// the runs which do not repeat at a fixed period are counted one at a time, up to a limit.
+	tr = ByTimestamp(true).SetDates(1, 2, 3, 4, 5, 6, 7, 8, 9, 10).SetSeconds(0).EveryNth(2, from)
+	if _, err := tr.NextAfter(from.AddDate(1, 0, 0)); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Timer.NextAfter() error = %v, want %v past %d runs", err, ErrNoMatch, maxCountedRuns)
+	}
+}
@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// IntervalMode decides what the interval of an Interval is measured from.
+type IntervalMode int
+
+const (
+	// FixedDelay runs the scheduler one interval after the time it is scheduled from with Next,
+	// ie: one interval after the previous run completed. It is the default mode.
+	FixedDelay IntervalMode = iota
+	// FixedRate runs the scheduler on the ticks start+k·interval, whatever the time the previous runs took,
+	// start being the anchor of the scheduler or, when it is not anchored, its first run armed with Next.
+	FixedRate
+)
+
+// String returns the name of the interval mode.
+func (m IntervalMode) String() string {
+	switch m {
+	case FixedDelay:
+		return "fixed-delay"
+	case FixedRate:
+		return "fixed-rate"
+	}
+	return fmt.Sprintf("IntervalMode(%d)", int(m))
+}
+
+// MissedPolicy decides how an Interval running at a fixed rate runs the ticks it missed,
+// eg: while the previous run took longer than the interval.
+type MissedPolicy int
+
+const (
+	// MissedSkip skips the missed ticks, the scheduler running next on the first tick to come.
+	// It is the default policy.
+	MissedSkip MissedPolicy = iota
+	// MissedFireOnce runs the scheduler once right away for all the missed ticks, then on the first tick to come.
+	MissedFireOnce
+	// MissedFireAll runs the scheduler right away for each missed tick, one after the other, until it caught up.
+	MissedFireAll
+)
+
+// String returns the name of the missed policy.
+func (p MissedPolicy) String() string {
+	switch p {
+	case MissedSkip:
+		return "skip"
+	case MissedFireOnce:
+		return "fire-once"
+	case MissedFireAll:
+		return "fire-all"
+	}
+	return fmt.Sprintf("MissedPolicy(%d)", int(p))
+}
+
+// SetMode sets what the interval of the scheduler is measured from. The default is FixedDelay.
+// An anchored scheduler keeps to the ticks of its anchor in either mode, see SetAnchor.
+//
+// eg:
+//	...
+//	i.AddMinute(5).SetMode(schedule.FixedRate)   // will run the scheduler every 5 minutes from its first run, however long the runs take.
+//	...
+func (i Interval) SetMode(m IntervalMode) Interval {
+	if m != FixedDelay && m != FixedRate {
+		i.invalid("unknown interval mode %d", int(m))
+		return i
+	}
+	i.mode = m
+	return i
+}
+
+// SetMissedPolicy sets how the scheduler runs the ticks it missed when it keeps to a fixed rate, ie: when it is anchored
+// or in FixedRate mode. A tick is missed when the scheduler is next scheduled after it with Next, Wait or C. The default is MissedSkip.
+//
+// eg:
+//	...
+//	i.AddMinute(5).SetMode(schedule.FixedRate).SetMissedPolicy(schedule.MissedFireOnce)   // will run right away after a run longer than 5 minutes.
+//	...
+func (i Interval) SetMissedPolicy(p MissedPolicy) Interval {
+	if p != MissedSkip && p != MissedFireOnce && p != MissedFireAll {
+		i.invalid("unknown missed policy %d", int(p))
+		return i
+	}
+	i.missed = p
+	return i
+}
+
+// arm returns the time the run found at next from now is due at, and the time it runs at. A scheduler in FixedRate mode
+// is anchored on its first run, and a scheduler keeping to a fixed rate runs the ticks it missed right away, without jitter,
+// as per the missed policy. It is shared by Next, Wait and C, so they all keep to the same ticks.
+func (i *Interval) arm(now, next time.Time) (due, at time.Time) {
+	if i.mode == FixedRate && i.anchor.IsZero() {
+		// the ticks of the scheduler are counted from its first run.
+		i.anchor = next
+	}
+	if i.anchor.IsZero() {
+		return next, i.spread(now, next)
+	}
+	if due, i.due = i.catchUp(now, next); due.Equal(now) {
+		return now, now
+	}
+	return next, i.spread(now, next)
+}
+
+// catchUp returns the time of the run of a scheduler keeping to a fixed rate, given the first tick after now,
+// along with the time up to which its ticks are run, the missed ticks being run right away as per the missed policy.
+func (i *Interval) catchUp(now, next time.Time) (at, ran time.Time) {
+	if i.due.IsZero() || i.missed == MissedSkip {
+		return next, next
+	}
+	first, err := i.NextAfter(i.due)
+	if err != nil || !first.Before(next) {
+		return next, next
+	}
+
+	// the ticks from first to now were missed.
+	if i.missed == MissedFireAll {
+		return now, first
+	}
+	return now, now
+}
@@ -0,0 +1,131 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIntervalMode_String(t *testing.T) {
+	tests := []struct {
+		mode IntervalMode
+		want string
+	}{
+		{FixedDelay, "fixed-delay"},
+		{FixedRate, "fixed-rate"},
+		{IntervalMode(7), "IntervalMode(7)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.mode.String(); got != tt.want {
+				t.Errorf("IntervalMode.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissedPolicy_String(t *testing.T) {
+	tests := []struct {
+		policy MissedPolicy
+		want   string
+	}{
+		{MissedSkip, "skip"},
+		{MissedFireOnce, "fire-once"},
+		{MissedFireAll, "fire-all"},
+		{MissedPolicy(7), "MissedPolicy(7)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.policy.String(); got != tt.want {
+				t.Errorf("MissedPolicy.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterval_SetMode_invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval Interval
+	}{
+		{name: "mode", interval: ByFreq(true).AddMinute(5).SetMode(IntervalMode(7))},
+		{name: "missed policy", interval: ByFreq(true).AddMinute(5).SetMissedPolicy(MissedPolicy(-1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.interval.NextAfter(clk.Now()); err == nil {
+				t.Errorf("Interval.NextAfter() error = nil, want error")
+			}
+		})
+	}
+}
+
+// intervals arms the scheduler with Next, the task of each run taking the given time, and returns the intervals armed.
+func intervals(t *testing.T, i Interval, c *FakeClock, took ...time.Duration) []time.Duration {
+	t.Helper()
+	var got []time.Duration
+	var s Scheduler = &i
+	for _, d := range took {
+		var err error
+		if s, err = s.Next(); err != nil {
+			t.Fatalf("Interval.Next() error = %v", err)
+		}
+		armed := s.(*Interval).interval
+		got = append(got, armed)
+		c.Advance(armed + d)
+	}
+	return got
+}
+
+func TestInterval_Next_mode(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		interval func(i Interval) Interval
+		took     []time.Duration
+		want     []time.Duration
+	}{
+		{
+			name:     "fixed delay drifts by the time the runs took",
+			interval: func(i Interval) Interval { return i },
+			took:     []time.Duration{time.Minute, 2 * time.Minute, 0},
+			want:     []time.Duration{5 * time.Minute, 5 * time.Minute, 5 * time.Minute},
+		}, {
+			name:     "fixed rate keeps to the ticks of the first run",
+			interval: func(i Interval) Interval { return i.SetMode(FixedRate) },
+			took:     []time.Duration{time.Minute, 2 * time.Minute, 0},
+			want:     []time.Duration{5 * time.Minute, 4 * time.Minute, 3 * time.Minute},
+		}, {
+			name:     "missed ticks skipped",
+			interval: func(i Interval) Interval { return i.SetMode(FixedRate) },
+			took:     []time.Duration{12 * time.Minute, 0},
+			want:     []time.Duration{5 * time.Minute, 3 * time.Minute},
+		}, {
+			name:     "missed ticks run once",
+			interval: func(i Interval) Interval { return i.SetMode(FixedRate).SetMissedPolicy(MissedFireOnce) },
+			took:     []time.Duration{12 * time.Minute, 0, 0},
+			want:     []time.Duration{5 * time.Minute, 0, 3 * time.Minute},
+		}, {
+			name:     "missed ticks all run",
+			interval: func(i Interval) Interval { return i.SetMode(FixedRate).SetMissedPolicy(MissedFireAll) },
+			took:     []time.Duration{12 * time.Minute, 0, 0, 0},
+			want:     []time.Duration{5 * time.Minute, 0, 0, 3 * time.Minute},
+		}, {
+			name: "anchored keeps to its ticks",
+			interval: func(i Interval) Interval {
+				return i.SetAnchor(start.Add(time.Minute)).SetMissedPolicy(MissedFireOnce)
+			},
+			took: []time.Duration{7 * time.Minute, 0},
+			want: []time.Duration{time.Minute, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewFakeClock(start)
+			i := tt.interval(ByFreq(true).AddMinute(5).SetClock(c))
+			if got := intervals(t, i, c, tt.took...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Interval.Next() armed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,721 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frequency is the FREQ of a recurrence rule, from the finest to the coarsest.
+type frequency int
+
+const (
+	secondly frequency = iota
+	minutely
+	hourly
+	daily
+	weekly
+	monthly
+	yearly
+)
+
+// frequencies are the names of the frequencies in recurrence rules.
+var frequencies = map[string]frequency{
+	"SECONDLY": secondly,
+	"MINUTELY": minutely,
+	"HOURLY":   hourly,
+	"DAILY":    daily,
+	"WEEKLY":   weekly,
+	"MONTHLY":  monthly,
+	"YEARLY":   yearly,
+}
+
+// weekdays are the names of the weekdays in recurrence rules.
+var weekdays = map[string]Weekday{
+	"SU": Sunday,
+	"MO": Monday,
+	"TU": Tuesday,
+	"WE": Wednesday,
+	"TH": Thursday,
+	"FR": Friday,
+	"SA": Saturday,
+}
+
+// nthDay is a BYDAY value, eg: {Monday, -1} for the last Monday of the month or year, {Monday, 0} for every Monday.
+type nthDay struct {
+	day Weekday
+	n   int
+}
+
+// recurrence is a parsed RFC 5545 recurrence rule.
+type recurrence struct {
+	freq      frequency
+	interval  int       // number of periods between two periods of the rule.
+	count     int       // number of occurrences after which the rule ends, 0 for no limit.
+	until     time.Time // last time of the rule, a wall time unless untilUTC.
+	untilUTC  bool      // if true, until is an instant rather than a wall time.
+	months    []int
+	weekNos   []int
+	yearDays  []int
+	monthDays []int
+	days      []nthDay
+	hours     []int
+	minutes   []int
+	seconds   []int
+	setPos    []int
+	wkst      Weekday // first day of the week, for WEEKLY rules and BYWEEKNO.
+}
+
+// RRule is a scheduler running on the occurrences of an RFC 5545 recurrence rule, eg: as exported by a calendar app.
+// The rule is evaluated in the wall clock of the location of its DTSTART.
+//
+// eg:
+//	...
+//	r, err := schedule.ParseRRule("DTSTART;TZID=Europe/Berlin:20200106T090000\n" +
+//		"RRULE:FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=-1;COUNT=10")   // will run on the last Monday or Tuesday of 10 months, at 09:00.
+//	...
+type RRule struct {
+	schedule
+	rule    *recurrence // parsed rule, shared by the copies of the scheduler.
+	text    string      // rule as given, for String.
+	start   time.Time   // DTSTART, the first possible occurrence.
+	dtWall  time.Time   // wall time of DTSTART as given, read as if it was in UTC, even when it is skipped by daylight saving.
+	exdates *Calendar   // EXDATE, the occurrences left out.
+}
+
+// ParseRRule parses a recurrence rule, either on its own, eg: "FREQ=WEEKLY;BYDAY=MO", or as the DTSTART, RRULE
+// and EXDATE lines of an iCalendar event. A rule given on its own needs its DTSTART set with SetDTStart.
+// Times without a TZID nor a trailing Z are read in UTC, EXDATE ones in the location of DTSTART.
+//
+// eg:
+//	...
+//	r, err := schedule.ParseRRule("DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;INTERVAL=2\nEXDATE:20200105T090000Z")
+//	...
+func ParseRRule(text string) (*RRule, error) {
+	r := &RRule{schedule: newSched(true, setCtx(nil), systemClock)}
+	var exdates []string
+	var exparams []map[string]string
+
+	lines := icsLines(strings.NewReader(text))
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if !strings.Contains(line, ":") {
+			if err := r.parseRule(line); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		name, params, value, err := icsProp(line)
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "RRULE":
+			err = r.parseRule(value)
+		case "DTSTART":
+			if r.start, _, err = icsTime(params, value, time.UTC); err == nil {
+				// the wall time is kept as given, as the instant of a DTSTART skipped by daylight saving is shifted.
+				r.dtWall, _, err = icsTime(nil, value, time.UTC)
+			}
+		case "EXDATE":
+			exdates, exparams = append(exdates, value), append(exparams, params)
+		default:
+			err = fmt.Errorf("unexpected property %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	if r.rule == nil {
+		return nil, fmt.Errorf("missing RRULE")
+	}
+
+	// the floating EXDATE are read in the location of DTSTART, which may come after them.
+	loc := time.UTC
+	if !r.start.IsZero() {
+		loc = r.start.Location()
+	}
+	r.exdates = NewCalendar(loc)
+	for i, value := range exdates {
+		for _, v := range strings.Split(value, ",") {
+			t, date, err := icsTime(exparams[i], v, loc)
+			if err != nil {
+				return nil, fmt.Errorf("EXDATE: %v", err)
+			}
+			if date {
+				r.exdates.ExcludeDate(t.Year(), Month(t.Month()), t.Day())
+				continue
+			}
+			r.exdates.ExcludeRange(t, t.Add(time.Nanosecond))
+		}
+	}
+	return r, nil
+}
+
+// parseRule parses the parts of the value of an RRULE.
+func (r *RRule) parseRule(value string) error {
+	if r.rule != nil {
+		return fmt.Errorf("more than one RRULE")
+	}
+	rule := &recurrence{freq: -1, interval: 1, wkst: Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(strings.ToUpper(value), ";") {
+		name, v, ok := strings.Cut(part, "=")
+		if !ok || v == "" {
+			return fmt.Errorf("invalid rule part %q", part)
+		}
+		if seen[name] {
+			return fmt.Errorf("%s given more than once", name)
+		}
+		seen[name] = true
+		if err := rule.set(name, v); err != nil {
+			return err
+		}
+	}
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	r.rule, r.text = rule, strings.ToUpper(value)
+	return nil
+}
+
+// set parses a part of a recurrence rule.
+func (rule *recurrence) set(name, v string) (err error) {
+	switch name {
+	case "FREQ":
+		f, ok := frequencies[v]
+		if !ok {
+			return fmt.Errorf("unknown FREQ %q", v)
+		}
+		rule.freq = f
+	case "INTERVAL":
+		rule.interval, err = strconv.Atoi(v)
+		if err != nil || rule.interval < 1 {
+			return fmt.Errorf("INTERVAL %q must be a positive number", v)
+		}
+	case "COUNT":
+		rule.count, err = strconv.Atoi(v)
+		if err != nil || rule.count < 1 {
+			return fmt.Errorf("COUNT %q must be a positive number", v)
+		}
+	case "UNTIL":
+		var date bool
+		if rule.until, date, err = icsTime(nil, v, time.UTC); err != nil {
+			return fmt.Errorf("UNTIL: %v", err)
+		}
+		if date {
+			// an UNTIL date includes the whole day.
+			rule.until = rule.until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		rule.untilUTC = strings.HasSuffix(v, "Z")
+	case "WKST":
+		d, ok := weekdays[v]
+		if !ok {
+			return fmt.Errorf("unknown WKST %q", v)
+		}
+		rule.wkst = d
+	case "BYDAY":
+		rule.days, err = parseNthDays(v)
+	case "BYMONTH":
+		rule.months, err = parseInts(name, v, 1, 12, false)
+	case "BYWEEKNO":
+		rule.weekNos, err = parseInts(name, v, 1, 53, true)
+	case "BYYEARDAY":
+		rule.yearDays, err = parseInts(name, v, 1, 366, true)
+	case "BYMONTHDAY":
+		rule.monthDays, err = parseInts(name, v, 1, 31, true)
+	case "BYHOUR":
+		rule.hours, err = parseInts(name, v, 0, 23, false)
+	case "BYMINUTE":
+		rule.minutes, err = parseInts(name, v, 0, 59, false)
+	case "BYSECOND":
+		rule.seconds, err = parseInts(name, v, 0, 59, false)
+	case "BYSETPOS":
+		rule.setPos, err = parseInts(name, v, 1, 366, true)
+	default:
+		return fmt.Errorf("unknown rule part %s", name)
+	}
+	return
+}
+
+// validate checks the combinations of rule parts which RFC 5545 rules out.
+func (rule *recurrence) validate() error {
+	switch {
+	case rule.freq < 0:
+		return fmt.Errorf("missing FREQ")
+	case rule.count > 0 && !rule.until.IsZero():
+		return fmt.Errorf("COUNT and UNTIL must not be given together")
+	case len(rule.weekNos) > 0 && rule.freq != yearly:
+		return fmt.Errorf("BYWEEKNO is only allowed with FREQ=YEARLY")
+	case len(rule.yearDays) > 0 && rule.freq >= daily && rule.freq <= monthly:
+		return fmt.Errorf("BYYEARDAY is not allowed with FREQ=DAILY, WEEKLY or MONTHLY")
+	case len(rule.monthDays) > 0 && rule.freq == weekly:
+		return fmt.Errorf("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	for _, d := range rule.days {
+		if d.n == 0 {
+			continue
+		}
+		if rule.freq != monthly && rule.freq != yearly {
+			return fmt.Errorf("BYDAY with an occurrence is only allowed with FREQ=MONTHLY or YEARLY")
+		}
+		if len(rule.weekNos) > 0 {
+			return fmt.Errorf("BYDAY with an occurrence is not allowed with BYWEEKNO")
+		}
+	}
+	return nil
+}
+
+// parseInts parses a comma separated list of numbers between min and max, or between -max and -min when negatives are allowed.
+func parseInts(name, v string, min, max int, negative bool) ([]int, error) {
+	var vals []int
+	for _, s := range strings.Split(v, ",") {
+		n, err := strconv.Atoi(s)
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < min || abs > max {
+			if negative {
+				return nil, fmt.Errorf("%s value %q must be between %d and %d, or -%d and -%d", name, s, min, max, max, min)
+			}
+			return nil, fmt.Errorf("%s value %q must be between %d and %d", name, s, min, max)
+		}
+		vals = append(vals, n)
+	}
+	return vals, nil
+}
+
+// parseNthDays parses a comma separated list of BYDAY values, eg: MO,-1FR,+2TU
+func parseNthDays(v string) ([]nthDay, error) {
+	var days []nthDay
+	for _, s := range strings.Split(v, ",") {
+		if len(s) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", s)
+		}
+		d, ok := weekdays[s[len(s)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", s)
+		}
+		n := 0
+		if num := s[:len(s)-2]; num != "" {
+			var err error
+			n, err = strconv.Atoi(num)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("occurrence of BYDAY value %q must be between 1 and 53, or -53 and -1", s)
+			}
+		}
+		days = append(days, nthDay{day: d, n: n})
+	}
+	return days, nil
+}
+
+// SetDTStart sets the DTSTART of the rule, its first possible occurrence, whose location the rule is evaluated in.
+//
+// eg:
+//	...
+//	r.SetDTStart(time.Date(2020, time.January, 6, 9, 0, 0, 0, berlin))   // will run the rule from the 6th of January 2020 at 09:00.
+//	...
+func (r RRule) SetDTStart(t time.Time) RRule {
+	r.start, r.dtWall = t, wall(t)
+	return r
+}
+
+// AddExDate leaves the occurrences at the given times out of the rule.
+//
+// eg:
+//	...
+//	r.AddExDate(christmas)   // will not run the rule on the occurrence at christmas.
+//	...
+func (r RRule) AddExDate(ts ...time.Time) RRule {
+	ex := Calendar{location: time.UTC}
+	if r.exdates != nil {
+		ex = *r.exdates
+	}
+	ex.ranges = append([]blackout(nil), ex.ranges...)
+	for _, t := range ts {
+		ex.ExcludeRange(t, t.Add(time.Nanosecond))
+	}
+	r.exdates = &ex
+	return r
+}
+
+// SetClock sets the clock of the scheduler, which is used to tell the current time.
+// The system clock is used by default.
+//
+// eg:
+//	...
+//	r.SetClock(schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func (r RRule) SetClock(c Clock) RRule {
+	r.setClock(c)
+	return r
+}
+
+// SetCalendar sets the calendar of the dates and time ranges on which the scheduler must not run.
+// The excluded occurrences are skipped, as with EXDATE.
+//
+// eg:
+//	...
+//	r.SetCalendar(holidays)   // will not run the rule on holidays.
+//	...
+func (r RRule) SetCalendar(c *Calendar) RRule {
+	r.calendar = c
+	return r
+}
+
+// Next finds the next occurrence of the rule from the current time of its clock and prepares the scheduler to run.
+func (r RRule) Next() (Scheduler, error) {
+	now := r.timeSource().Now()
+	next, err := r.NextAfter(now)
+	if err != nil {
+		return nil, err
+	}
+	r.timer = next
+	r.interval = next.Sub(now)
+	return &r, nil
+}
+
+// NextAfter returns the first occurrence of the rule after t which is neither an EXDATE nor excluded by the calendar.
+//
+// eg:
+//	...
+//	next, err := r.NextAfter(time.Now())   // will return the time of the next occurrence.
+//	...
+func (r RRule) NextAfter(t time.Time) (time.Time, error) {
+	if r.start.IsZero() {
+		return time.Time{}, fmt.Errorf("the rule needs a DTSTART, see SetDTStart")
+	}
+	allowed := func(t time.Time) (time.Time, error) {
+		next, err := r.occurrenceAfter(t)
+		if err != nil {
+			return next, err
+		}
+		return r.exdates.skip(next, r.occurrenceAfter)
+	}
+	next, err := allowed(t)
+	if err != nil {
+		return next, err
+	}
+	return r.calendar.skip(next, allowed)
+}
+
+// occurrenceAfter returns the first occurrence of the rule after t, going through the periods of the rule.
+func (r *RRule) occurrenceAfter(t time.Time) (time.Time, error) {
+	loc := r.start.Location()
+	start := r.dtWall
+	rule := r.rule.withDefaults(start)
+
+	// the occurrences before t need to be counted when the rule has a COUNT, otherwise the periods before t are skipped.
+	k, n := 0, 0
+	if rule.count == 0 && t.After(r.start) {
+		k = rule.periodAt(start, wall(t.In(loc)))
+	}
+	for empty := 0; empty < maxCandidates; k++ {
+		from, to := rule.period(start, k)
+		if rule.after(from, loc) {
+			break
+		}
+
+		// the periods shorter than a day of the days which do not match are skipped all at once.
+		if rule.freq < daily && !rule.matchDay(from, from.Year()) {
+			next := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+			k = rule.periodAt(start, next)
+			if at, _ := rule.period(start, k); !at.Before(next) {
+				k--
+			}
+			empty++
+			continue
+		}
+
+		occurrences := rule.occurrences(from, to, start)
+		if len(occurrences) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, o := range occurrences {
+			if o.Before(start) {
+				continue
+			}
+			if rule.after(o, loc) {
+				return time.Time{}, fmt.Errorf("no occurrence of the rule after %s, it ends before", t.Format(time.RFC3339))
+			}
+			if n++; rule.count > 0 && n > rule.count {
+				return time.Time{}, fmt.Errorf("no occurrence of the rule after %s, it ends after %d occurrences", t.Format(time.RFC3339), rule.count)
+			}
+			if at := instant(o, loc); at.After(t) {
+				return at, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("no occurrence of the rule after %s", t.Format(time.RFC3339))
+}
+
+// wall returns the wall time of t, read as if it was in UTC, which keeps the arithmetic on it free of daylight saving shifts.
+func wall(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// instant returns the instant the wall clock of the location shows the wall time w at.
+// A wall time skipped by daylight saving is read with the offset in effect before the gap, as RFC 5545 requires.
+func instant(w time.Time, loc *time.Location) time.Time {
+	t := time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+	if wall(t).Equal(w) {
+		return t
+	}
+	d := wallOf(w)
+	d.location = loc
+	before, _ := d.offsets()
+	return w.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+// withDefaults returns a copy of the rule with the parts left out taken from the wall time of DTSTART,
+// eg: the month and day of month of DTSTART for a YEARLY rule without any BYxxx day part.
+func (rule *recurrence) withDefaults(start time.Time) *recurrence {
+	d := *rule
+	if len(d.weekNos) == 0 && len(d.yearDays) == 0 && len(d.monthDays) == 0 && len(d.days) == 0 {
+		switch d.freq {
+		case yearly:
+			if len(d.months) == 0 {
+				d.months = []int{int(start.Month())}
+			}
+			d.monthDays = []int{start.Day()}
+		case monthly:
+			d.monthDays = []int{start.Day()}
+		case weekly:
+			d.days = []nthDay{{day: Weekday(start.Weekday())}}
+		}
+	}
+	if len(d.hours) == 0 && d.freq > hourly {
+		d.hours = []int{start.Hour()}
+	}
+	if len(d.minutes) == 0 && d.freq > minutely {
+		d.minutes = []int{start.Minute()}
+	}
+	if len(d.seconds) == 0 && d.freq > secondly {
+		d.seconds = []int{start.Second()}
+	}
+	return &d
+}
+
+// after reports whether the wall time w is after the UNTIL of the rule.
+func (rule *recurrence) after(w time.Time, loc *time.Location) bool {
+	switch {
+	case rule.until.IsZero():
+		return false
+	case rule.untilUTC:
+		return instant(w, loc).After(rule.until)
+	}
+	return w.After(rule.until)
+}
+
+// period returns the wall times the k-th period of the rule starts and ends at, counting from the one of start.
+func (rule *recurrence) period(start time.Time, k int) (from, to time.Time) {
+	y, m, d := start.Date()
+	n := k * rule.interval
+	switch rule.freq {
+	case yearly:
+		from = time.Date(y+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(1, 0, 0)
+	case monthly:
+		from = time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(0, 1, 0)
+	case weekly:
+		from = rule.weekStart(start).AddDate(0, 0, 7*n)
+		return from, from.AddDate(0, 0, 7)
+	case daily:
+		from = time.Date(y, m, d+n, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(0, 0, 1)
+	case hourly:
+		from = time.Date(y, m, d, start.Hour()+n, 0, 0, 0, time.UTC)
+		return from, from.Add(time.Hour)
+	case minutely:
+		from = time.Date(y, m, d, start.Hour(), start.Minute()+n, 0, 0, time.UTC)
+		return from, from.Add(time.Minute)
+	}
+	from = time.Date(y, m, d, start.Hour(), start.Minute(), start.Second()+n, 0, time.UTC)
+	return from, from.Add(time.Second)
+}
+
+// periodAt returns the index of the period of the rule which the wall time w is in, or the one before it when w is
+// between two periods of the rule, counting from the one of start.
+func (rule *recurrence) periodAt(start, w time.Time) int {
+	var n int64
+	switch rule.freq {
+	case yearly:
+		n = int64(w.Year() - start.Year())
+	case monthly:
+		n = int64((w.Year()-start.Year())*12 + int(w.Month()-start.Month()))
+	case weekly:
+		n = (rule.weekStart(w).Unix() - rule.weekStart(start).Unix()) / (7 * 86400)
+	case daily:
+		n = (w.Truncate(24*time.Hour).Unix() - start.Truncate(24*time.Hour).Unix()) / 86400
+	case hourly:
+		n = (w.Unix() - start.Truncate(time.Hour).Unix()) / 3600
+	case minutely:
+		n = (w.Unix() - start.Truncate(time.Minute).Unix()) / 60
+	default:
+		n = w.Unix() - start.Unix()
+	}
+	if n < 0 {
+		return 0
+	}
+	return int(n / int64(rule.interval))
+}
+
+// weekStart returns the first day of the week of the wall time w, at midnight.
+func (rule *recurrence) weekStart(w time.Time) time.Time {
+	back := (int(w.Weekday()) - int(rule.wkst) + 7) % 7
+	return time.Date(w.Year(), w.Month(), w.Day()-back, 0, 0, 0, 0, time.UTC)
+}
+
+// week1Start returns the first day of the first week of the year, which is the first week with at least 4 days in the year.
+func (rule *recurrence) week1Start(year int) time.Time {
+	return rule.weekStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC))
+}
+
+// occurrences returns the wall times of the occurrences of the rule within the period, in increasing order.
+func (rule *recurrence) occurrences(from, to, start time.Time) []time.Time {
+	year := from.Year()
+
+	// the days of the period, the weeks of a year with BYWEEKNO overlapping the years around it.
+	var days []time.Time
+	first, last := from, to
+	if rule.freq == yearly && len(rule.weekNos) > 0 {
+		first, last = rule.week1Start(year), rule.week1Start(year+1)
+	}
+	if rule.freq < daily {
+		first, last = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC), from.Add(time.Nanosecond)
+	}
+	for d := first; d.Before(last); d = d.AddDate(0, 0, 1) {
+		if rule.matchDay(d, year) {
+			days = append(days, d)
+		}
+	}
+
+	hours := rule.expand(rule.hours, hourly, from.Hour())
+	minutes := rule.expand(rule.minutes, minutely, from.Minute())
+	seconds := rule.expand(rule.seconds, secondly, from.Second())
+
+	var ws []time.Time
+	for _, d := range days {
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, s := range seconds {
+					ws = append(ws, time.Date(d.Year(), d.Month(), d.Day(), h, m, s, start.Nanosecond(), time.UTC))
+				}
+			}
+		}
+	}
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Before(ws[j]) })
+	if len(rule.setPos) == 0 {
+		return ws
+	}
+
+	// BYSETPOS picks among the occurrences of the period, counting from its end when negative.
+	var picked []time.Time
+	for _, p := range rule.setPos {
+		i := p - 1
+		if p < 0 {
+			i = len(ws) + p
+		}
+		if i >= 0 && i < len(ws) {
+			picked = append(picked, ws[i])
+		}
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i].Before(picked[j]) })
+	uniq := picked[:0]
+	for i, w := range picked {
+		if i == 0 || !w.Equal(picked[i-1]) {
+			uniq = append(uniq, w)
+		}
+	}
+	return uniq
+}
+
+// expand returns the values of a time unit of the occurrences within a period.
+// The values of the units coarser than the frequency are the BYxxx ones, while the unit of the frequency and the ones
+// finer than it are fixed by the period, at val, and only limited by the BYxxx values.
+func (rule *recurrence) expand(vals []int, unit frequency, val int) []int {
+	if rule.freq > unit {
+		return vals
+	}
+	if len(vals) == 0 || containsInt(vals, val) {
+		return []int{val}
+	}
+	return nil
+}
+
+// matchDay reports whether the day matches the BYxxx day parts of the rule, the weeks being numbered within the year.
+func (rule *recurrence) matchDay(d time.Time, year int) bool {
+	dim := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	diy := time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+
+	if len(rule.months) > 0 && !containsInt(rule.months, int(d.Month())) {
+		return false
+	}
+	if len(rule.weekNos) > 0 {
+		start, end := rule.week1Start(year), rule.week1Start(year+1)
+		week := int(d.Sub(start)/(7*24*time.Hour)) + 1
+		weeks := int(end.Sub(start) / (7 * 24 * time.Hour))
+		if !containsInt(rule.weekNos, week) && !containsInt(rule.weekNos, week-weeks-1) {
+			return false
+		}
+	}
+	if len(rule.yearDays) > 0 && !containsInt(rule.yearDays, d.YearDay()) && !containsInt(rule.yearDays, d.YearDay()-diy-1) {
+		return false
+	}
+	if len(rule.monthDays) > 0 && !containsInt(rule.monthDays, d.Day()) && !containsInt(rule.monthDays, d.Day()-dim-1) {
+		return false
+	}
+	if len(rule.days) == 0 {
+		return true
+	}
+
+	// the occurrences of BYDAY are counted within the month, or within the year for YEARLY rules without BYMONTH.
+	nth, fromEnd := (d.Day()-1)/7+1, -((dim-d.Day())/7 + 1)
+	if rule.freq == yearly && len(rule.months) == 0 {
+		nth, fromEnd = (d.YearDay()-1)/7+1, -((diy-d.YearDay())/7 + 1)
+	}
+	for _, nd := range rule.days {
+		if int(nd.day) == int(d.Weekday()) && (nd.n == 0 || nd.n == nth || nd.n == fromEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsInt reports whether the values contain the given value.
+func containsInt(vals []int, val int) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the rule, from its DTSTART, and the time until its next occurrence.
+// eg:
+//	...
+//	r, _ := schedule.ParseRRule("DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;COUNT=5")
+//	fmt.Println(r.String()) // will print: DTSTART:20200101T090000Z RRULE:FREQ=DAILY;COUNT=5 -> next execution in 0s
+//	...
+func (r *RRule) String() string {
+	rule := "RRULE:" + r.text
+	switch {
+	case r.start.IsZero():
+	case r.start.Location() == time.UTC:
+		rule = "DTSTART:" + r.start.Format("20060102T150405Z") + " " + rule
+	default:
+		rule = "DTSTART;TZID=" + r.start.Location().String() + ":" + r.dtWall.Format("20060102T150405") + " " + rule
+	}
+	return fmt.Sprintf("%s -> next execution in %s", rule, r.interval)
+}
@@ -0,0 +1,326 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	_ "time/tzdata"
+)
+
+func TestRRule_NextAfter(t *testing.T) {
+	nyc, _ := time.LoadLocation("America/New_York")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	at := func(loc *time.Location, y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, loc)
+	}
+	ny := func(y int, m time.Month, d int) time.Time { return at(nyc, y, m, d, 9, 0) }
+	utc := func(y int, m time.Month, d, h, min int) time.Time { return at(time.UTC, y, m, d, h, min) }
+
+	tests := []struct {
+		name    string
+		rule    string
+		after   time.Time // the first occurrences are looked for when zero.
+		n       int
+		want    []time.Time
+		wantErr bool
+	}{
+		{
+			name: "daily count",
+			rule: "DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;COUNT=3",
+			n:    3,
+			want: []time.Time{utc(2020, 1, 1, 9, 0), utc(2020, 1, 2, 9, 0), utc(2020, 1, 3, 9, 0)},
+		}, {
+			name:    "beyond count",
+			rule:    "DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;COUNT=3",
+			n:       4,
+			wantErr: true,
+			want:    []time.Time{utc(2020, 1, 1, 9, 0), utc(2020, 1, 2, 9, 0), utc(2020, 1, 3, 9, 0)},
+		}, {
+			name: "last monday or tuesday of the month",
+			rule: "DTSTART;TZID=Europe/Berlin:20200106T090000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=-1;COUNT=10",
+			n:    3,
+			want: []time.Time{at(berlin, 2020, 1, 28, 9, 0), at(berlin, 2020, 2, 25, 9, 0), at(berlin, 2020, 3, 31, 9, 0)},
+		}, {
+			name: "every other week until",
+			rule: "DTSTART;TZID=America/New_York:19970901T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			n:    7,
+			want: []time.Time{
+				ny(1997, 9, 1), ny(1997, 9, 3), ny(1997, 9, 5), ny(1997, 9, 15), ny(1997, 9, 17), ny(1997, 9, 19), ny(1997, 9, 29),
+			},
+		}, {
+			name:    "after until",
+			rule:    "DTSTART;TZID=America/New_York:19970901T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			after:   ny(1997, 12, 19),
+			n:       2,
+			want:    []time.Time{ny(1997, 12, 22)},
+			wantErr: true,
+		}, {
+			name: "first friday of the month",
+			rule: "DTSTART;TZID=America/New_York:19970905T090000\nRRULE:FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			n:    5,
+			want: []time.Time{ny(1997, 9, 5), ny(1997, 10, 3), ny(1997, 11, 7), ny(1997, 12, 5), ny(1998, 1, 2)},
+		}, {
+			name: "friday the 13th",
+			rule: "DTSTART;TZID=America/New_York:19970902T090000\nEXDATE;TZID=America/New_York:19970902T090000\nRRULE:FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			n:    5,
+			want: []time.Time{ny(1998, 2, 13), ny(1998, 3, 13), ny(1998, 11, 13), ny(1999, 8, 13), ny(2000, 10, 13)},
+		}, {
+			name: "yearly in june and july",
+			rule: "DTSTART;TZID=America/New_York:19970610T090000\nRRULE:FREQ=YEARLY;COUNT=10;BYMONTH=6,7",
+			n:    4,
+			want: []time.Time{ny(1997, 6, 10), ny(1997, 7, 10), ny(1998, 6, 10), ny(1998, 7, 10)},
+		}, {
+			name: "monday of week 20",
+			rule: "DTSTART;TZID=America/New_York:19970512T090000\nRRULE:FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			n:    3,
+			want: []time.Time{ny(1997, 5, 12), ny(1998, 5, 11), ny(1999, 5, 17)},
+		}, {
+			name: "week 1 starting in the previous year",
+			rule: "DTSTART:20191230T090000Z\nRRULE:FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO",
+			n:    3,
+			want: []time.Time{utc(2019, 12, 30, 9, 0), utc(2021, 1, 4, 9, 0), utc(2022, 1, 3, 9, 0)},
+		}, {
+			name: "20th monday of the year",
+			rule: "DTSTART;TZID=America/New_York:19970519T090000\nRRULE:FREQ=YEARLY;BYDAY=20MO",
+			n:    3,
+			want: []time.Time{ny(1997, 5, 19), ny(1998, 5, 18), ny(1999, 5, 17)},
+		}, {
+			name: "third tuesday, wednesday or thursday of the month",
+			rule: "DTSTART;TZID=America/New_York:19970904T090000\nRRULE:FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			n:    3,
+			want: []time.Time{ny(1997, 9, 4), ny(1997, 10, 7), ny(1997, 11, 6)},
+		}, {
+			name: "every 3 hours until",
+			rule: "DTSTART:19970902T090000Z\nRRULE:FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z",
+			n:    3,
+			want: []time.Time{utc(1997, 9, 2, 9, 0), utc(1997, 9, 2, 12, 0), utc(1997, 9, 2, 15, 0)},
+		}, {
+			name: "every 15 minutes",
+			rule: "DTSTART:19970902T090000Z\nRRULE:FREQ=MINUTELY;INTERVAL=15;COUNT=6",
+			n:    6,
+			want: []time.Time{
+				utc(1997, 9, 2, 9, 0), utc(1997, 9, 2, 9, 15), utc(1997, 9, 2, 9, 30),
+				utc(1997, 9, 2, 9, 45), utc(1997, 9, 2, 10, 0), utc(1997, 9, 2, 10, 15),
+			},
+		}, {
+			name: "last day of the month",
+			rule: "DTSTART:20200115T000000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=-1",
+			n:    3,
+			want: []time.Time{utc(2020, 1, 31, 0, 0), utc(2020, 2, 29, 0, 0), utc(2020, 3, 31, 0, 0)},
+		}, {
+			name: "leap days",
+			rule: "DTSTART:20200229T120000Z\nRRULE:FREQ=YEARLY",
+			n:    3,
+			want: []time.Time{utc(2020, 2, 29, 12, 0), utc(2024, 2, 29, 12, 0), utc(2028, 2, 29, 12, 0)},
+		}, {
+			name: "exdates counted",
+			rule: "DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;COUNT=5\nEXDATE:20200103T090000Z\nEXDATE;VALUE=DATE:20200104",
+			n:    3,
+			want: []time.Time{utc(2020, 1, 1, 9, 0), utc(2020, 1, 2, 9, 0), utc(2020, 1, 5, 9, 0)},
+		}, {
+			name: "hourly on saturdays",
+			rule: "DTSTART:20200101T000000Z\nRRULE:FREQ=HOURLY;INTERVAL=6;BYDAY=SA",
+			n:    5,
+			want: []time.Time{utc(2020, 1, 4, 0, 0), utc(2020, 1, 4, 6, 0), utc(2020, 1, 4, 12, 0), utc(2020, 1, 4, 18, 0), utc(2020, 1, 11, 0, 0)},
+		}, {
+			name: "yearly by year day",
+			rule: "DTSTART:20200101T000000Z\nRRULE:FREQ=YEARLY;BYYEARDAY=1,-1",
+			n:    3,
+			want: []time.Time{utc(2020, 1, 1, 0, 0), utc(2020, 12, 31, 0, 0), utc(2021, 1, 1, 0, 0)},
+		}, {
+			name: "daily by hour and minute",
+			rule: "DTSTART:20200101T000000Z\nRRULE:FREQ=DAILY;BYHOUR=9,17;BYMINUTE=0,30",
+			n:    5,
+			want: []time.Time{
+				utc(2020, 1, 1, 9, 0), utc(2020, 1, 1, 9, 30), utc(2020, 1, 1, 17, 0), utc(2020, 1, 1, 17, 30), utc(2020, 1, 2, 9, 0),
+			},
+		}, {
+			name:  "far from start",
+			rule:  "DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY",
+			after: utc(2050, 6, 1, 10, 0),
+			n:     2,
+			want:  []time.Time{utc(2050, 6, 2, 9, 0), utc(2050, 6, 3, 9, 0)},
+		}, {
+			name: "daylight saving",
+			rule: "DTSTART;TZID=Europe/Berlin:20200328T090000\nRRULE:FREQ=DAILY",
+			n:    2,
+			want: []time.Time{utc(2020, 3, 28, 8, 0), utc(2020, 3, 29, 7, 0)},
+		}, {
+			name: "start skipped by daylight saving",
+			rule: "DTSTART;TZID=America/New_York:20200308T023000\nRRULE:FREQ=DAILY",
+			n:    3,
+			want: []time.Time{at(nyc, 2020, 3, 8, 3, 30), at(nyc, 2020, 3, 9, 2, 30), at(nyc, 2020, 3, 10, 2, 30)},
+		}, {
+			name: "occurrence skipped by daylight saving",
+			rule: "DTSTART;TZID=America/New_York:20200307T023000\nRRULE:FREQ=DAILY",
+			n:    3,
+			want: []time.Time{at(nyc, 2020, 3, 7, 2, 30), at(nyc, 2020, 3, 8, 3, 30), at(nyc, 2020, 3, 9, 2, 30)},
+		}, {
+			name:    "never",
+			rule:    "DTSTART:20200101T000000Z\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			n:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule() error = %v", err)
+			}
+			after := tt.after
+			if after.IsZero() {
+				after = r.start.Add(-time.Nanosecond)
+			}
+			got, err := NextN(r, after, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RRule.NextAfter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("RRule.NextAfter() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("RRule.NextAfter() = %v, want %v", got, tt.want)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestRRule_until(t *testing.T) {
+	r, err := ParseRRule("DTSTART;TZID=America/New_York:19970901T090000\nRRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	got, _ := Between(r, r.start, r.start.AddDate(1, 0, 0))
+	if len(got) != 25 {
+		t.Errorf("Between() = %d occurrences, want 25", len(got))
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr string
+	}{
+		{name: "bare rule", rule: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "rrule line", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO"},
+		{name: "missing rrule", rule: "DTSTART:20200101T000000Z", wantErr: "missing RRULE"},
+		{name: "missing freq", rule: "COUNT=2", wantErr: "missing FREQ"},
+		{name: "unknown freq", rule: "FREQ=FORTNIGHTLY", wantErr: `unknown FREQ "FORTNIGHTLY"`},
+		{name: "unknown part", rule: "FREQ=DAILY;BYMOON=1", wantErr: "unknown rule part BYMOON"},
+		{name: "invalid part", rule: "FREQ=DAILY;COUNT", wantErr: `invalid rule part "COUNT"`},
+		{name: "repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: "FREQ given more than once"},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20200101", wantErr: "COUNT and UNTIL must not be given together"},
+		{name: "interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: `INTERVAL "0" must be a positive number`},
+		{name: "month", rule: "FREQ=YEARLY;BYMONTH=13", wantErr: `BYMONTH value "13" must be between 1 and 12`},
+		{name: "month day", rule: "FREQ=MONTHLY;BYMONTHDAY=-32", wantErr: `BYMONTHDAY value "-32" must be between 1 and 31, or -31 and -1`},
+		{name: "weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: `invalid BYDAY value "XX"`},
+		{name: "occurrence", rule: "FREQ=MONTHLY;BYDAY=0MO", wantErr: `occurrence of BYDAY value "0MO" must be between 1 and 53, or -53 and -1`},
+		{name: "occurrence in weekly rule", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: "BYDAY with an occurrence is only allowed with FREQ=MONTHLY or YEARLY"},
+		{name: "week number in monthly rule", rule: "FREQ=MONTHLY;BYWEEKNO=1", wantErr: "BYWEEKNO is only allowed with FREQ=YEARLY"},
+		{name: "more than one rule", rule: "FREQ=DAILY\nRRULE:FREQ=WEEKLY", wantErr: "RRULE: more than one RRULE"},
+		{name: "unexpected property", rule: "FREQ=DAILY\nSUMMARY:x", wantErr: "SUMMARY: unexpected property SUMMARY"},
+		{name: "invalid exdate", rule: "FREQ=DAILY\nEXDATE:x", wantErr: `EXDATE: invalid date "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ParseRRule() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParseRRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRRule_SetDTStart(t *testing.T) {
+	r, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,FR")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	if _, err := r.NextAfter(time.Now()); err == nil {
+		t.Errorf("RRule.NextAfter() error = nil, want error without DTSTART")
+	}
+
+	start := time.Date(2020, time.January, 6, 8, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2020, time.January, d, 8, 0, 0, 0, time.UTC) }
+	s := r.SetDTStart(start).AddExDate(day(10))
+	got, err := NextN(&s, start, 3)
+	if err != nil {
+		t.Fatalf("NextN() error = %v", err)
+	}
+	if want := []time.Time{day(13), day(17), day(20)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RRule.NextAfter() = %v, want %v", got, want)
+	}
+
+	// the copy left the original rule untouched.
+	if r.exdates.Excludes(day(10)) {
+		t.Errorf("RRule.AddExDate() changed the EXDATE of the original rule")
+	}
+}
+
+func TestRRule_Next(t *testing.T) {
+	c := NewFakeClock(time.Date(2020, time.December, 23, 12, 0, 0, 0, time.UTC))
+	r, err := ParseRRule("DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	holidays := NewCalendar(nil).ExcludeDate(2020, December, 24)
+
+	s, err := r.SetClock(c).SetCalendar(holidays).Next()
+	if err != nil {
+		t.Fatalf("RRule.Next() error = %v", err)
+	}
+	if got, want := s.(*RRule).timer, time.Date(2020, time.December, 25, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("RRule.Next() = %v, want %v", got, want)
+	}
+}
+
+func TestRRule_String(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want string
+	}{
+		{
+			name: "utc",
+			rule: "DTSTART:20200101T090000Z\nRRULE:FREQ=DAILY;COUNT=5",
+			want: "DTSTART:20200101T090000Z RRULE:FREQ=DAILY;COUNT=5 -> next execution in 0s",
+		}, {
+			name: "time zone",
+			rule: "DTSTART;TZID=Europe/Berlin:20200101T090000\nRRULE:freq=weekly",
+			want: "DTSTART;TZID=Europe/Berlin:20200101T090000 RRULE:FREQ=WEEKLY -> next execution in 0s",
+		}, {
+			name: "start skipped by daylight saving",
+			rule: "DTSTART;TZID=America/New_York:20200308T023000\nRRULE:FREQ=DAILY",
+			want: "DTSTART;TZID=America/New_York:20200308T023000 RRULE:FREQ=DAILY -> next execution in 0s",
+		}, {
+			name: "without start",
+			rule: "FREQ=DAILY",
+			want: "RRULE:FREQ=DAILY -> next execution in 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule() error = %v", err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("RRule.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -27,6 +28,42 @@ const (
 	maxYear = 9999
 )
 
+// limits is the range of values allowed for each time unit of a Timer.
+var limits = map[timeUnit][2]int{
+	year:   {1, maxYear},
+	month:  {int(January), int(December)},
+	day:    {int(Sunday), int(Saturday)},
+	date:   {1, 31},
+	hour:   {0, 23},
+	minute: {0, 59},
+	second: {0, 59},
+}
+
+// String returns the name of the time unit.
+func (u timeUnit) String() string {
+	switch u {
+	case year:
+		return "year"
+	case month:
+		return "month"
+	case week:
+		return "week"
+	case day:
+		return "day"
+	case date:
+		return "date"
+	case hour:
+		return "hour"
+	case minute:
+		return "minute"
+	case second:
+		return "second"
+	case nsec:
+		return "nanosecond"
+	}
+	return fmt.Sprintf("timeUnit(%d)", int(u))
+}
+
 // Month type is a replacement for time.Month.
 // This is to support custom schedule types.
 type Month int
@@ -62,19 +99,41 @@ const (
 	Saturday
 )
 
+// Scheduler is implemented by the interval and timestamp based schedulers.
 type Scheduler interface {
+	// Next prepares the scheduler to run on its next schedule from the current time of its clock.
 	Next() (Scheduler, error)
 	String() string
 }
 
+// Previewer is implemented by the schedulers which tell their runs without running, as every scheduler of the package does.
+// NextN, Between, Iterate and the Composite schedulers look at the runs of the schedulers they are given through it.
+type Previewer interface {
+	// NextAfter returns the first time after t at which the scheduler runs, without preparing it to run.
+	NextAfter(t time.Time) (time.Time, error)
+}
+
 // schedule is the main controller of scheduler pkg.
 type schedule struct {
 	repeat      bool            // if true, scheduler will run forever as long as requirements are met.
 	schedEveryN int             // if > 0, scheduler will run every N interval / timestamp whichever is specified.
 	interval    time.Duration   // frequency of interval based scheduler.
 	timer       time.Time       // time for next schedule.
-	tick        *time.Ticker    // ticker for the schedule which keeps the scheduler logic in wait.
+	tick        ClockTicker     // ticker for the schedule which keeps the scheduler logic in wait.
 	context     context.Context // context for the scheduler. To control scheduler cancel.
+	clock       Clock           // source of time for the scheduler.
+	anchor      time.Time       // first run of an anchored Interval, the next ones following one interval apart.
+	mode        IntervalMode    // what the interval of an Interval is measured from.
+	missed      MissedPolicy    // how an Interval keeping to a fixed rate runs the ticks it missed.
+	due         time.Time       // time the last run armed with Next was due at, before the jitter: the runs up to it are done.
+	jitter      jitter          // random spread of the runs of the scheduler.
+	calendar    *Calendar       // dates and time ranges on which the scheduler must not run.
+	everyFrom   time.Time       // start of the count of the runs of a Timer running every N runs, zero when not counted.
+	runs        *runCount       // runs of a Timer counted from everyFrom.
+	start       time.Time       // time before which the scheduler does not run, zero when unbounded.
+	end         time.Time       // time after which the scheduler does not run, zero when unbounded.
+	maxRuns     int             // number of runs armed with Next after which the scheduler is exhausted, 0 when unbounded.
+	fired       *int64          // number of runs armed with Next, shared by the copies of the scheduler.
 	dur         *duration       // duration for the scheduler is a verbose struct with each time unit in raw format.
 }
 
@@ -90,23 +149,70 @@ type duration struct {
 	Second   int
 	Nsec     int
 	location *time.Location
-}
 
-// now always returns the current time.
-var now = func() time.Time { return time.Now().UTC() }
+	sets         map[timeUnit][]int     // values allowed for each time unit of a Timer, a unit missing from the map allows every value.
+	dayOrDate    bool                   // if true, a Timer matches a day when either its date or its weekday matches.
+	lastDate     bool                   // if true, a Timer matches the last date of the month.
+	nearestDates []int                  // dates of a Timer which move to the nearest weekday of the month when falling on weekends.
+	lastDays     []int                  // weekdays of a Timer which match when they are the last of them in the month.
+	nthDays      [][2]int               // weekday and occurrence pairs of a Timer, eg: {Tuesday, 2} for the second Tuesday of the month.
+	hashKey      string                 // key the hashed time units of a Timer are derived from.
+	hashes       map[timeUnit]hashRange // ranges the time units of a Timer are hashed into.
+	gap          GapPolicy              // how a Timer runs on the wall times skipped by daylight saving transitions.
+	overlap      OverlapPolicy          // how a Timer runs on the wall times repeated by daylight saving transitions.
+	err          error                  // first invalid value given to the Timer builders, reported when scheduling.
+	logger       Logger                 // receiver of the trace of the search of the runs, nil to log nothing.
+}
 
-// newSched sets up a new scheduler with given context.
-func newSched(repeat bool, ctx context.Context) schedule {
+// newSched sets up a new scheduler with given context and clock.
+func newSched(repeat bool, ctx context.Context, clock Clock) schedule {
+	if clock == nil {
+		clock = systemClock
+	}
 	return schedule{
 		repeat:  repeat,
-		timer:   now(),
+		timer:   clock.Now(),
 		context: ctx,
+		clock:   clock,
 		dur: &duration{
 			location: time.UTC,
 		},
 	}
 }
 
+// setClock sets the clock for the scheduler, restarting the schedule from the current time of the clock.
+func (s *schedule) setClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock
+	}
+	s.clock = clock
+	s.timer, s.due = clock.Now(), time.Time{}
+}
+
+// invalid records an invalid value given to the builders, to be reported when scheduling as an error wrapping ErrInvalid.
+// Only the first invalid value is kept.
+func (s *schedule) invalid(format string, args ...interface{}) {
+	if s.dur.err == nil {
+		s.dur.err = fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+	}
+}
+
+// invalidField records a value given to the builders out of the range of the field, as a *FieldError.
+// Only the first invalid value is kept.
+func (s *schedule) invalidField(field string, val, min, max int) {
+	if s.dur.err == nil {
+		s.dur.err = &FieldError{Field: field, Value: val, Min: min, Max: max}
+	}
+}
+
+// timeSource returns the clock of the scheduler, falling back to the system clock when none is set.
+func (s *schedule) timeSource() Clock {
+	if s.clock == nil {
+		return systemClock
+	}
+	return s.clock
+}
+
 // setCtx sets the context for the scheduler.
 // if no ctx is provided from user, default ctx is set
 func setCtx(ctx []context.Context) context.Context {
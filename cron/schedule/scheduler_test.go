@@ -9,26 +9,35 @@ import (
 
 var ctx context.Context
 
+// clk is the clock shared by the tests which do not advance time.
+var clk *FakeClock
+
 func init() {
 	ctx = context.Background()
-	now()
-	now = func() time.Time { return time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC) }
+	clk = NewFakeClock(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
 }
 
-func Test_now(t *testing.T) {
+func Test_schedule_timeSource(t *testing.T) {
 	tests := []struct {
-		name string
-		want time.Time
+		name  string
+		clock Clock
+		want  Clock
 	}{
 		{
-			name: "now",
-			want: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			name:  "clock set",
+			clock: clk,
+			want:  clk,
+		}, {
+			name:  "no clock",
+			clock: nil,
+			want:  systemClock,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := now(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("now() = %v, want %v", got, tt.want)
+			s := &schedule{clock: tt.clock}
+			if got := s.timeSource(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schedule.timeSource() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -38,6 +47,7 @@ func Test_newSched(t *testing.T) {
 	type args struct {
 		repeat bool
 		ctx    context.Context
+		clock  Clock
 	}
 
 	tests := []struct {
@@ -50,10 +60,12 @@ func Test_newSched(t *testing.T) {
 			args: args{
 				repeat: true,
 				ctx:    ctx,
+				clock:  clk,
 			},
 			want: schedule{
 				repeat:      true,
-				timer:       now(),
+				timer:       clk.Now(),
+				clock:       clk,
 				context:     ctx,
 				dur:         &duration{location: time.UTC},
 				schedEveryN: 0,
@@ -65,10 +77,12 @@ func Test_newSched(t *testing.T) {
 			args: args{
 				repeat: false,
 				ctx:    ctx,
+				clock:  clk,
 			},
 			want: schedule{
 				repeat:      false,
-				timer:       now(),
+				timer:       clk.Now(),
+				clock:       clk,
 				context:     ctx,
 				dur:         &duration{location: time.UTC},
 				schedEveryN: 0,
@@ -79,7 +93,7 @@ func Test_newSched(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := newSched(tt.args.repeat, tt.args.ctx); !reflect.DeepEqual(got, tt.want) {
+			if got := newSched(tt.args.repeat, tt.args.ctx, tt.args.clock); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("newSched() = %v, want %v", got, tt.want)
 			}
 		})
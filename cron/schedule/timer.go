@@ -3,6 +3,9 @@ package schedule
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
 	"time"
 )
 
@@ -14,7 +17,20 @@ type Timer struct {
 // ByTimestamp returns a new clock time based scheduler.
 func ByTimestamp(repeat bool, ctx ...context.Context) *Timer {
 	return &Timer{
-		newSched(repeat, setCtx(ctx)),
+		newSched(repeat, setCtx(ctx), systemClock),
+	}
+}
+
+// ByTimestampWithClock returns a new clock time based scheduler driven by the given clock, eg: a FakeClock in tests.
+// A nil clock stands for the system clock.
+//
+// eg:
+//	...
+//	t := schedule.ByTimestampWithClock(true, schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func ByTimestampWithClock(repeat bool, c Clock, ctx ...context.Context) *Timer {
+	return &Timer{
+		newSched(repeat, setCtx(ctx), c),
 	}
 }
 
@@ -41,10 +57,12 @@ func (t Timer) SetMonth(val Month) Timer {
 }
 
 // SetDate sets the date of the scheduler.
+// In months which do not have the date, the scheduler runs on the last date of the month instead.
 //
 // eg:
 //	...
 //	t.SetDate(29)   // will set the date of the scheduler to 29th of the month in given year.
+//	t.SetDate(31)   // will run the scheduler on the 31st, or the 30th, 29th or 28th in shorter months.
 //	...
 func (t Timer) SetDate(val int) Timer {
 	t.set(date, val)
@@ -117,9 +135,463 @@ func (t Timer) SetLocation(loc *time.Location) Timer {
 	return t
 }
 
+// SetGapPolicy sets how the scheduler runs on the wall times skipped by a forward daylight saving transition of its location.
+// The default is GapShiftForward.
+//
+// eg:
+//	...
+//	t.SetHour(2).SetMinute(30).SetLocation(ny).SetGapPolicy(schedule.GapSkip)   // will not run the scheduler on the night 02:30 does not exist.
+//	...
+func (t Timer) SetGapPolicy(p GapPolicy) Timer {
+	if p != GapShiftForward && p != GapSkip {
+		t.invalid("unknown gap policy %d", int(p))
+		return t
+	}
+	t.dur.gap = p
+	return t
+}
+
+// SetOverlapPolicy sets how the scheduler runs on the wall times repeated by a backward daylight saving transition of its location.
+// The default is OverlapFireOnce.
+//
+// eg:
+//	...
+//	t.SetHour(1).SetMinute(30).SetLocation(ny).SetOverlapPolicy(schedule.OverlapFireTwice)   // will run the scheduler twice on the night 01:30 happens twice.
+//	...
+func (t Timer) SetOverlapPolicy(p OverlapPolicy) Timer {
+	if p != OverlapFireOnce && p != OverlapFireTwice {
+		t.invalid("unknown overlap policy %d", int(p))
+		return t
+	}
+	t.dur.overlap = p
+	return t
+}
+
+// SetMonths sets the months of the year in which the scheduler runs.
+//
+// eg:
+//	...
+//	t.SetMonths(schedule.January, schedule.July)   // will run the scheduler in January and July.
+//	...
+func (t Timer) SetMonths(vals ...Month) Timer {
+	ints := make([]int, len(vals))
+	for i, v := range vals {
+		ints[i] = int(v)
+	}
+	t.setValues(month, ints)
+	return t
+}
+
+// SetMonthRange sets the range of months in which the scheduler runs, both months included.
+// A range wraps around the end of the year when from is after to.
+//
+// eg:
+//	...
+//	t.SetMonthRange(schedule.November, schedule.February)   // will run the scheduler from November to February.
+//	...
+func (t Timer) SetMonthRange(from, to Month) Timer {
+	t.setRange(month, int(from), int(to))
+	return t
+}
+
+// SetMonthStep sets the scheduler to run every step months, starting from January.
+//
+// eg:
+//	...
+//	t.SetMonthStep(3)   // will run the scheduler in January, April, July and October.
+//	...
+func (t Timer) SetMonthStep(step int) Timer {
+	t.setStep(month, step)
+	return t
+}
+
+// SetDates sets the dates of the month on which the scheduler runs.
+// Months which do not have a given date are skipped for that date.
+//
+// eg:
+//	...
+//	t.SetDates(1, 15)   // will run the scheduler on the 1st and 15th of the month.
+//	...
+func (t Timer) SetDates(vals ...int) Timer {
+	t.setValues(date, vals)
+	return t
+}
+
+// SetDateRange sets the range of dates of the month on which the scheduler runs, both dates included.
+// A range wraps around the end of the month when from is after to.
+//
+// eg:
+//	...
+//	t.SetDateRange(1, 7)   // will run the scheduler during the first week of the month.
+//	...
+func (t Timer) SetDateRange(from, to int) Timer {
+	t.setRange(date, from, to)
+	return t
+}
+
+// SetDateStep sets the scheduler to run every step dates of the month, starting from the 1st.
+//
+// eg:
+//	...
+//	t.SetDateStep(2)   // will run the scheduler on the odd dates of the month.
+//	...
+func (t Timer) SetDateStep(step int) Timer {
+	t.setStep(date, step)
+	return t
+}
+
+// SetLastDate sets the scheduler to run on the last date of the month, taking leap years into account.
+// It runs in addition to the dates set with SetDate or SetDates.
+//
+// eg:
+//	...
+//	t.SetLastDate()   // will run the scheduler on the 31st of January, the 28th or 29th of February, the 30th of April, ...
+//	...
+func (t Timer) SetLastDate() Timer {
+	t.dur.lastDate = true
+	return t
+}
+
+// SetNearestWeekday sets the scheduler to run on the weekday nearest to the given date of the month.
+// A date falling on a weekend moves to the closest Friday or Monday, without leaving the month,
+// and a date beyond the end of the month moves to the last date first.
+// It runs in addition to the dates set with SetDate or SetDates.
+//
+// eg:
+//	...
+//	t.SetNearestWeekday(15)   // will run the scheduler on the 15th, or on the 14th if it is a Saturday, or on the 16th if it is a Sunday.
+//	t.SetNearestWeekday(31)   // will run the scheduler on the last weekday of the month.
+//	...
+func (t Timer) SetNearestWeekday(val int) Timer {
+	if val < 1 || val > 31 {
+		t.invalidField("nearest weekday date", val, 1, 31)
+		return t
+	}
+	t.dur.nearestDates = append(t.dur.nearestDates, val)
+	return t
+}
+
+// SetDays sets the weekdays on which the scheduler runs.
+//
+// eg:
+//	...
+//	t.SetDays(schedule.Saturday, schedule.Sunday)   // will run the scheduler on weekends.
+//	...
+func (t Timer) SetDays(vals ...Weekday) Timer {
+	ints := make([]int, len(vals))
+	for i, v := range vals {
+		ints[i] = int(v)
+	}
+	t.setValues(day, ints)
+	return t
+}
+
+// SetDayRange sets the range of weekdays on which the scheduler runs, both days included.
+// A range wraps around the end of the week when from is after to.
+//
+// eg:
+//	...
+//	t.SetDayRange(schedule.Monday, schedule.Friday)   // will run the scheduler on working days.
+//	...
+func (t Timer) SetDayRange(from, to Weekday) Timer {
+	t.setRange(day, int(from), int(to))
+	return t
+}
+
+// SetDayStep sets the scheduler to run every step weekdays, starting from Sunday.
+//
+// eg:
+//	...
+//	t.SetDayStep(2)   // will run the scheduler on Sunday, Tuesday, Thursday and Saturday.
+//	...
+func (t Timer) SetDayStep(step int) Timer {
+	t.setStep(day, step)
+	return t
+}
+
+// SetLastDay sets the scheduler to run on the last given weekday of the month.
+// It runs in addition to the weekdays set with SetDay or SetDays.
+//
+// eg:
+//	...
+//	t.SetLastDay(schedule.Friday)   // will run the scheduler on the last Friday of the month.
+//	...
+func (t Timer) SetLastDay(val Weekday) Timer {
+	if val < Sunday || val > Saturday {
+		t.invalidField("last day", int(val), int(Sunday), int(Saturday))
+		return t
+	}
+	t.dur.lastDays = append(t.dur.lastDays, int(val))
+	return t
+}
+
+// SetNthDay sets the scheduler to run on the nth given weekday of the month, where n is between 1 and 5.
+// It runs in addition to the weekdays set with SetDay or SetDays.
+//
+// eg:
+//	...
+//	t.SetNthDay(schedule.Tuesday, 2)   // will run the scheduler on the second Tuesday of the month.
+//	...
+func (t Timer) SetNthDay(val Weekday, n int) Timer {
+	if val < Sunday || val > Saturday {
+		t.invalidField("nth day", int(val), int(Sunday), int(Saturday))
+		return t
+	}
+	if n < 1 || n > 5 {
+		t.invalidField("nth day occurrence", n, 1, 5)
+		return t
+	}
+	t.dur.nthDays = append(t.dur.nthDays, [2]int{int(val), n})
+	return t
+}
+
+// SetDayOrDate sets the scheduler to run on a day matching either the dates or the weekdays,
+// when both of them are set. By default, a day has to match both, as every other time unit.
+//
+// eg:
+//	...
+//	t.SetDates(1).SetDays(schedule.Monday).SetDayOrDate(true)   // will run the scheduler on the 1st of the month and on Mondays.
+//	...
+func (t Timer) SetDayOrDate(either bool) Timer {
+	t.dur.dayOrDate = either
+	return t
+}
+
+// SetHours sets the hours of the day at which the scheduler runs.
+//
+// eg:
+//	...
+//	t.SetHours(0, 12)   // will run the scheduler at midnight and noon.
+//	...
+func (t Timer) SetHours(vals ...int) Timer {
+	t.setValues(hour, vals)
+	return t
+}
+
+// SetHourRange sets the range of hours of the day at which the scheduler runs, both hours included.
+// A range wraps around midnight when from is after to.
+//
+// eg:
+//	...
+//	t.SetHourRange(9, 17)   // will run the scheduler during business hours.
+//	...
+func (t Timer) SetHourRange(from, to int) Timer {
+	t.setRange(hour, from, to)
+	return t
+}
+
+// SetHourStep sets the scheduler to run every step hours, starting from midnight.
+//
+// eg:
+//	...
+//	t.SetHourStep(6)   // will run the scheduler at 0, 6, 12 and 18 hours.
+//	...
+func (t Timer) SetHourStep(step int) Timer {
+	t.setStep(hour, step)
+	return t
+}
+
+// SetMinutes sets the minutes of the hour at which the scheduler runs.
+//
+// eg:
+//	...
+//	t.SetMinutes(0, 15, 30, 45)   // will run the scheduler every quarter hour.
+//	...
+func (t Timer) SetMinutes(vals ...int) Timer {
+	t.setValues(minute, vals)
+	return t
+}
+
+// SetMinuteRange sets the range of minutes of the hour at which the scheduler runs, both minutes included.
+// A range wraps around the end of the hour when from is after to.
+//
+// eg:
+//	...
+//	t.SetMinuteRange(0, 9)   // will run the scheduler during the first 10 minutes of the hour.
+//	...
+func (t Timer) SetMinuteRange(from, to int) Timer {
+	t.setRange(minute, from, to)
+	return t
+}
+
+// SetMinuteStep sets the scheduler to run every step minutes, starting from the beginning of the hour.
+//
+// eg:
+//	...
+//	t.SetMinuteStep(5)   // will run the scheduler every 5 minutes.
+//	...
+func (t Timer) SetMinuteStep(step int) Timer {
+	t.setStep(minute, step)
+	return t
+}
+
+// SetSeconds sets the seconds of the minute at which the scheduler runs.
+//
+// eg:
+//	...
+//	t.SetSeconds(0, 30)   // will run the scheduler every half a minute.
+//	...
+func (t Timer) SetSeconds(vals ...int) Timer {
+	t.setValues(second, vals)
+	return t
+}
+
+// SetSecondRange sets the range of seconds of the minute at which the scheduler runs, both seconds included.
+// A range wraps around the end of the minute when from is after to.
+//
+// eg:
+//	...
+//	t.SetSecondRange(0, 4)   // will run the scheduler during the first 5 seconds of the minute.
+//	...
+func (t Timer) SetSecondRange(from, to int) Timer {
+	t.setRange(second, from, to)
+	return t
+}
+
+// SetSecondStep sets the scheduler to run every step seconds, starting from the beginning of the minute.
+//
+// eg:
+//	...
+//	t.SetSecondStep(10)   // will run the scheduler every 10 seconds.
+//	...
+func (t Timer) SetSecondStep(step int) Timer {
+	t.setStep(second, step)
+	return t
+}
+
+// SetClock sets the clock of the scheduler, which is used to tell the current time and to wait for the schedule.
+// The system clock is used by default, or when the clock is nil.
+//
+// eg:
+//	...
+//	t.SetClock(schedule.NewFakeClock(start))   // will run the scheduler on virtual time starting at start.
+//	...
+func (t Timer) SetClock(c Clock) Timer {
+	t.setClock(c)
+	return t
+}
+
+// SetLogger sets the logger which receives the trace of the search of the runs of the scheduler, at LevelDebug:
+// each candidate date rejected, with the time unit which rejected it and the reason. Nothing is logged by default.
+//
+// eg:
+//	...
+//	t.SetLogger(schedule.NewTextLogger(os.Stderr, schedule.LevelDebug))   // will trace the search of the runs on stderr.
+//	...
+func (t Timer) SetLogger(l Logger) Timer {
+	t.setLogger(l)
+	return t
+}
+
+// SetJitter spreads the runs of the scheduler randomly, up to max before or after their time.
+// It replaces the jitter set with SetJitterPercent. Only the runs armed with Next are spread,
+// NextAfter and Prev keep returning the exact dates of the schedule.
+//
+// eg:
+//	...
+//	t.SetJitter(30 * time.Second)   // will run the scheduler up to 30 seconds before or after its dates.
+//	...
+func (t Timer) SetJitter(max time.Duration) Timer {
+	t.setJitter(max, 0)
+	return t
+}
+
+// SetJitterPercent spreads the runs of the scheduler randomly, up to the given percentage of the time
+// left until each run, before or after its time. It replaces the jitter set with SetJitter.
+//
+// eg:
+//	...
+//	t.SetJitterPercent(10)   // will run the scheduler up to 6 minutes before or after its dates, when they are an hour apart.
+//	...
+func (t Timer) SetJitterPercent(pct float64) Timer {
+	t.setJitter(0, pct)
+	return t
+}
+
+// SetJitterSource sets the source of the random offsets of the runs, eg: a seeded source in tests.
+// The source is not safe for concurrent use, the default source of math/rand is used when none is set.
+//
+// eg:
+//	...
+//	t.SetJitter(time.Minute).SetJitterSource(rand.NewSource(42))   // will spread the runs the same way on every start.
+//	...
+func (t Timer) SetJitterSource(src rand.Source) Timer {
+	t.jitter.rand = rand.New(src)
+	return t
+}
+
+// SetCalendar sets the calendar of the dates and time ranges on which the scheduler must not run.
+// The excluded dates are skipped, the scheduler running on its first date which is not excluded.
+//
+// eg:
+//	...
+//	t.SetHours(16).SetMinutes(0).SetSeconds(0).SetCalendar(holidays)   // will run the scheduler at 16:00 on non-holidays.
+//	...
+func (t Timer) SetCalendar(c *Calendar) Timer {
+	t.calendar = c
+	return t
+}
+
+// EveryNth sets the scheduler to run only on every nth date matching the schedule, eg: every third Friday.
+// The dates are counted from the first one at or after from, so restarting the scheduler keeps its cadence.
+// The dates which repeat at a fixed period, eg: at set minutes of every hour, are counted by whole periods,
+// the others one at a time, scheduling failing past 100000 of them: the start of the count is to be kept close.
+// When from is not given, the scheduler runs on the nth matching date after the time it is scheduled from.
+// A date excluded by the calendar is skipped for the next nth date, eg: the 3rd Friday excluded, it runs on the 6th.
+//
+// eg:
+//	...
+//	t.SetDays(schedule.Friday).SetHours(9).SetMinutes(0).SetSeconds(0).EveryNth(3, start)   // will run the scheduler on every third Friday from start.
+//	...
+func (t Timer) EveryNth(n int, from ...time.Time) Timer {
+	t.everyNth(n)
+	if len(from) > 0 {
+		t.everyFrom = from[0]
+	}
+	return t
+}
+
+// StartAt sets the time before which the scheduler does not run, its first run being at or after it.
+//
+// eg:
+//	...
+//	t.SetHours(9).SetMinutes(0).SetSeconds(0).StartAt(launch)   // will run the scheduler at 09:00 from the day of the launch.
+//	...
+func (t Timer) StartAt(start time.Time) Timer {
+	t.setStart(start)
+	return t
+}
+
+// EndAt sets the time after which the scheduler does not run, its last run being at or before it.
+// Next fails with ErrScheduleExhausted once the end is passed.
+//
+// eg:
+//	...
+//	t.SetHours(9).SetMinutes(0).SetSeconds(0).EndAt(closing)   // will run the scheduler at 09:00 until the closing.
+//	...
+func (t Timer) EndAt(end time.Time) Timer {
+	t.setEnd(end)
+	return t
+}
+
+// MaxRuns sets the number of runs of the scheduler, counting the runs armed with Next.
+// Next fails with ErrScheduleExhausted once the scheduler has run n times.
+//
+// eg:
+//	...
+//	t.SetHours(9).SetMinutes(0).SetSeconds(0).MaxRuns(5)   // will run the scheduler at 09:00 on 5 days.
+//	...
+func (t Timer) MaxRuns(n int) Timer {
+	t.setMaxRuns(n)
+	return t
+}
+
 // set sets the time of execution for the scheduler based on the time unit.
+// It replaces any values previously set for the time unit.
 //
 func (t *Timer) set(units timeUnit, val int) {
+	delete(t.dur.sets, units)
+	delete(t.dur.hashes, units)
 	switch units {
 	case year:
 		t.dur.Year = val
@@ -140,212 +612,639 @@ func (t *Timer) set(units timeUnit, val int) {
 	}
 }
 
+// setValues sets the values allowed for the time unit, replacing the previous ones.
+// Setting no value allows every value of the time unit.
+func (t *Timer) setValues(units timeUnit, vals []int) {
+	delete(t.dur.hashes, units)
+	if len(vals) == 0 {
+		delete(t.dur.sets, units)
+		return
+	}
+	if t.dur.sets == nil {
+		t.dur.sets = map[timeUnit][]int{}
+	}
+
+	// keep the values sorted and unique, to look up the next allowed value
+	sorted := append([]int(nil), vals...)
+	sort.Ints(sorted)
+	uniq := sorted[:1]
+	for _, v := range sorted[1:] {
+		if v != uniq[len(uniq)-1] {
+			uniq = append(uniq, v)
+		}
+	}
+	t.dur.sets[units] = uniq
+}
+
+// setRange sets the values of the time unit from the first value to the last, both included.
+// When from is after to, the range wraps around the limits of the time unit.
+func (t *Timer) setRange(units timeUnit, from, to int) {
+	lim := limits[units]
+	if from < lim[0] || from > lim[1] || to < lim[0] || to > lim[1] {
+		// keep the invalid bounds, to be reported by validate
+		t.setValues(units, []int{from, to})
+		return
+	}
+
+	var vals []int
+	for v := from; v != to; v++ {
+		if v > lim[1] {
+			v = lim[0]
+			if v == to {
+				break
+			}
+		}
+		vals = append(vals, v)
+	}
+	t.setValues(units, append(vals, to))
+}
+
+// setStep sets the values of the time unit to every step value, starting from its lowest value.
+func (t *Timer) setStep(units timeUnit, step int) {
+	if step <= 0 {
+		t.invalid("%s step must be a positive number, given %d", units, step)
+		return
+	}
+
+	lim := limits[units]
+	var vals []int
+	for v := lim[0]; v <= lim[1]; v += step {
+		vals = append(vals, v)
+	}
+	t.setValues(units, vals)
+}
+
+// values returns the sorted values allowed for the time unit, nil when every value is allowed.
+func (d *duration) values(units timeUnit) []int {
+	if vals, ok := d.sets[units]; ok {
+		return vals
+	}
+
+	var val int
+	switch units {
+	case year:
+		val = d.Year
+	case month:
+		val = d.Month
+	case day:
+		val = d.Day
+	case date:
+		val = d.date
+	case hour:
+		val = d.Hour
+	case minute:
+		val = d.Minute
+	case second:
+		val = d.Second
+	}
+	// zero and schedule.Every are treated as unset, allowing every value
+	if val <= 0 {
+		return nil
+	}
+	return []int{val}
+}
+
+// nextValue returns the first value allowed for the time unit, which is equal or after the given value.
+// ok is false when no such value is within the limits of the time unit.
+func (d *duration) nextValue(units timeUnit, val int) (next int, ok bool) {
+	vals := d.values(units)
+	if vals == nil {
+		return val, val <= limits[units][1]
+	}
+	i := sort.SearchInts(vals, val)
+	if i == len(vals) {
+		return 0, false
+	}
+	return vals[i], true
+}
+
+// prevValue returns the last value allowed for the time unit, which is equal or before the given value.
+// ok is false when no such value is within the limits of the time unit.
+func (d *duration) prevValue(units timeUnit, val int) (prev int, ok bool) {
+	vals := d.values(units)
+	if vals == nil {
+		return val, val >= limits[units][0]
+	}
+	i := sort.SearchInts(vals, val+1)
+	if i == 0 {
+		return 0, false
+	}
+	return vals[i-1], true
+}
+
+// contains reports whether the sorted values contain the given value.
+func contains(vals []int, val int) bool {
+	i := sort.SearchInts(vals, val)
+	return i < len(vals) && vals[i] == val
+}
+
 // Init the scheduler and prepare for run
 func (t Timer) Next() (sched Scheduler, err error) {
 	var next time.Time
-	next, t.dur, err = t.dur.nextDate()
+	now := t.timeSource().Now()
+
+	// a run spread early by the jitter is not armed again, the search goes on from the time it was due at.
+	next, err = t.NextAfter(later(now, t.due))
 	if err != nil {
 		return nil, err
 	}
-	t.timer = next
-	t.tick = time.NewTicker(next.Sub(now()))
+	if err = t.countRun(); err != nil {
+		return nil, err
+	}
+	t.due = next
+	t.timer = t.spread(now, next)
+	t.tick = t.timeSource().NewTicker(t.timer.Sub(now))
+	t.dur.trace("run armed", "now", now, "next", t.timer)
 	return &t, err
 }
 
+// NextAfter returns the first date after t matching the schedule.
+// Unlike Next, it neither reads the clock nor arms the ticker of the scheduler.
+//
+// eg:
+//	...
+//	next, err := t.NextAfter(time.Now())   // will return the next date the scheduler would run on.
+//	...
+func (t Timer) NextAfter(after time.Time) (time.Time, error) {
+	nextDate := t.dur.nextDate
+	if n := t.nth(); n > 1 {
+		nextDate = func(after time.Time) (time.Time, error) {
+			if t.everyFrom.IsZero() {
+				return nthRun(n, after, t.dur.nextDate)
+			}
+			return t.runs.next(n, t.everyFrom, after, t.dur)
+		}
+	}
+	return t.boundNext(after, func(after time.Time) (time.Time, error) {
+		next, err := nextDate(after)
+		if err != nil {
+			return next, err
+		}
+		return t.calendar.skip(next, nextDate)
+	})
+}
+
+// Prev returns the last date before t matching the schedule,
+// eg: to tell after a restart whether a run was missed while the scheduler was down.
+//
+// eg:
+//	...
+//	prev, err := t.Prev(time.Now())   // will return the date the scheduler last ran on, or should have.
+//	...
+func (t Timer) Prev(before time.Time) (time.Time, error) {
+	prevDate := t.dur.prevDate
+	if n := t.nth(); n > 1 {
+		prevDate = func(before time.Time) (time.Time, error) {
+			if t.everyFrom.IsZero() {
+				return nthRun(n, before, t.dur.prevDate)
+			}
+			return t.runs.prev(n, t.everyFrom, before, t.dur)
+		}
+	}
+	return t.boundPrev(before, func(before time.Time) (time.Time, error) {
+		prev, err := prevDate(before)
+		if err != nil {
+			return prev, err
+		}
+		return t.calendar.skipBack(prev, prevDate)
+	})
+}
+
 // nextDate sets the next date of execution for the scheduler based on the time unit.
-func (d *duration) nextDate() (next time.Time, dur *duration, err error) {
+func (d *duration) nextDate(now time.Time) (next time.Time, err error) {
 
-	// validate duration for time based scheduler
-	if err = d.validate(); err != nil {
+	// validate a copy of the duration for time based scheduler, the duration being shared by the copies of the Timer.
+	if d, err = d.validated(); err != nil {
 		return
 	}
 
 	// set the next date of scheduler
-	if next, dur, err = d.findNextDate(); err != nil {
+	if next, err = d.findNextDate(now); err != nil {
 		return
 	}
 
 	// validate date
-	if !next.After(now()) || !next.After(now().Add(time.Minute)) {
-		return next, dur, fmt.Errorf("date must be after %s, given %s", now().Format(time.RFC3339), next.Format(time.RFC3339))
+	if !next.After(now) {
+		return next, fmt.Errorf("%w: date must be after %s, given %s", ErrInPast, now.Format(time.RFC3339), next.Format(time.RFC3339))
 	}
 	return
 }
 
-// findNextDate returns the next date of execution for the scheduler based on the time unit.
-func (d *duration) findNextDate() (updatedNext time.Time, durNext *duration, err error) {
+// prevDate returns the latest date before the given time which matches every time unit of the duration.
+func (d *duration) prevDate(before time.Time) (prev time.Time, err error) {
 
-	fmt.Printf("building next: %d, %d, %d, %d, %d, %d, %d, %v\n", d.Year, time.Month(d.Month), d.date, d.Hour, d.Minute, d.Second, d.Nsec, d.location)
-	next := time.Date(
-		normalize(d.Year), time.Month(normalize(d.Month)), normalize(d.date),
-		normalize(d.Hour), normalize(d.Minute), normalize(d.Second),
-		normalize(d.Nsec), d.location,
-	)
-	fmt.Println("next:", next, d)
-	// durNext is the duration of next date of scheduler
-	durNext = &duration{
-		Year:     next.Year(),
-		Month:    int(next.Month()),
-		Week:     d.Week,
-		Day:      int(next.Weekday()),
-		date:     next.Day(),
-		Hour:     next.Hour(),
-		Minute:   next.Minute(),
-		Second:   next.Second(),
-		Nsec:     next.Nanosecond(),
-		location: next.Location(),
+	// validate a copy of the duration for time based scheduler, the duration being shared by the copies of the Timer.
+	if d, err = d.validated(); err != nil {
+		return
 	}
-	fmt.Printf("durNext: %+v\n", durNext)
 
-	if d.Year == Every || d.Year < 1 {
-		durNext.Year = now().Year()
-	}
-	if d.Month == Every || d.Month < int(January) {
-		durNext.Month = int(now().Month())
-	}
-	if d.Day == Every || d.Day < int(Sunday) {
-		durNext.Day = int(now().Weekday())
-	}
-	if d.date == Every || d.date < 1 {
-		durNext.date = now().Day()
+	// end at the last whole second before the given time, taking the nanosecond of the schedule into account.
+	to := before.Add(-time.Duration(d.nsec()) - time.Nanosecond).Truncate(time.Second)
+
+	// move each time unit backward until all of them match the schedule, in the wall clock of the location.
+	d.trace("searching previous run", "before", before)
+	if prev, err = d.prevInstant(to); err != nil {
+		return
 	}
-	if d.Hour == Every || d.Hour < 0 {
-		durNext.Hour = now().Hour()
+	prev = prev.Add(time.Duration(d.nsec()))
+	d.trace("previous run found", "before", before, "prev", prev)
+	return prev, nil
+}
+
+// findNextDate returns the earliest date after now which matches every time unit of the duration.
+func (d *duration) findNextDate(now time.Time) (updatedNext time.Time, err error) {
+	d.trace("searching next run", "after", now)
+
+	// start from the first whole second after now, taking the nanosecond of the schedule into account.
+	from := now.Add(-time.Duration(d.nsec())).Truncate(time.Second).Add(time.Second)
+
+	// move each time unit forward until all of them match the schedule, in the wall clock of the location.
+	next, err := d.nextInstant(from)
+	if err != nil {
+		return
 	}
-	if d.Minute == Every || d.Minute < 0 {
-		durNext.Minute = now().Minute()
+
+	// set the next date of scheduler
+	updatedNext = next.Add(time.Duration(d.nsec()))
+	d.trace("next run found", "after", now, "next", updatedNext)
+	return
+}
+
+// nsec returns the nanosecond of the second at which the scheduler runs.
+func (d *duration) nsec() int {
+	if d.Nsec < 0 {
+		return 0
 	}
-	if d.Second == Every || d.Second < 0 {
-		durNext.Second = now().Second()
+	return d.Nsec
+}
+
+// calendars is the number of distinct calendars of the Gregorian years, which start on one of the 7 weekdays
+// and are leap years or not.
+const calendars = 14
+
+// calendarOf returns the calendar of the year, among the calendars: the weekday of its 1st of January, plus 7 for a leap year.
+func calendarOf(y int) int {
+	c := weekdayOf(y, int(January), 1)
+	if isLeap(y) {
+		c += 7
 	}
+	return c
+}
 
-	timeUnits := []timeUnit{second, minute, hour, day, date, month, year}
-	// for each time unit identify the next schedule time available
-	for i, unit := range timeUnits {
-		var updatedDur duration
+// update moves the date forward to the first date at or after it at which every time unit matches the schedule.
+// Each time unit which does not match jumps straight to the next allowed value, resetting the smaller time units,
+// or overflows into the larger ones. As the dates only depend on the calendar of the year, a year scanned from its start
+// without any match rules out every year sharing its calendar: at most 14 years are scanned, each in a few steps per month
+// looked at, the other years being skipped at once. The years scanned are the most for a date set on a weekday,
+// eg: February 29th on a Monday, which is first found up to 28 years ahead.
+func (d duration) update(sched *duration) (duration, error) {
+	var failed [calendars]bool
+	scanning, whole := d.Year, false
+	for {
+		// the year left behind was scanned from its start without any match.
+		if d.Year != scanning && whole {
+			failed[calendarOf(scanning)] = true
+		}
 
-		// update the scheduler timer, if error is returned, then return the error.
-		next, updatedDur, err = durNext.update(unit, next)
-		// if no errors are returned, it has successfully identified the next schedule time. break loop and set scheduler
-		if err == nil {
-			*durNext = updatedDur
-			break
+		// update the year to the next allowed year, skipping the years whose calendar is known not to match
+		next, ok := sched.nextValue(year, d.Year)
+		for ok && failed[calendarOf(next)] {
+			next, ok = sched.nextValue(year, next+1)
 		}
-		// if error is returned and the time unit is year, then return the error.
-		// since no more time units are available to identify the next schedule time.
-		if err != nil && i == len(timeUnits)-1 {
-			return
+		if !ok {
+			return d, fmt.Errorf("%w until year %d", ErrNoMatch, maxYear)
+		}
+		if next != d.Year {
+			sched.reject(year, d, yearReason(failed[calendarOf(d.Year)]))
+			d.Year, d.Month, d.date, d.Hour, d.Minute, d.Second = next, 1, 1, 0, 0, 0
+		}
+		if d.Year != scanning {
+			scanning, whole = d.Year, true
+		}
+
+		// update the month to the next allowed month, or the first month of next year
+		if next, ok := sched.nextValue(month, d.Month); !ok {
+			sched.reject(month, d, "no month is allowed after it in the year")
+			d.Year, d.Month, d.date, d.Hour, d.Minute, d.Second = d.Year+1, 1, 1, 0, 0, 0
+			continue
+		} else if next != d.Month {
+			sched.reject(month, d, "month is not allowed")
+			d.Month, d.date, d.Hour, d.Minute, d.Second = next, 1, 0, 0, 0
+		}
+
+		// update the date to the next day matching both date and weekday, or the first day of next month
+		if !sched.matchDate(d.Year, d.Month, d.date) {
+			next := sched.nextDateOf(d.Year, d.Month, d.date)
+			if next > daysOfMonth(d.Month, d.Year, d.location) {
+				sched.reject(date, d, "no date matching both date and weekday is left in the month")
+				d.Month, d.date, d.Hour, d.Minute, d.Second = d.Month+1, 1, 0, 0, 0
+				d.validateMonth()
+				continue
+			}
+			sched.reject(date, d, "date does not match both date and weekday")
+			d.date, d.Hour, d.Minute, d.Second = next, 0, 0, 0
 		}
-		fmt.Println(unit, durNext, next)
+
+		// update the hour to the next allowed hour, or the first hour of next day
+		if next, ok := sched.nextValue(hour, d.Hour); !ok {
+			sched.reject(hour, d, "no hour is allowed after it in the day")
+			d.date, d.Hour, d.Minute, d.Second = d.date+1, 0, 0, 0
+			d.validateDate()
+			continue
+		} else if next != d.Hour {
+			sched.reject(hour, d, "hour is not allowed")
+			d.Hour, d.Minute, d.Second = next, 0, 0
+		}
+
+		// update the minute to the next allowed minute, or the first minute of next hour
+		if next, ok := sched.nextValue(minute, d.Minute); !ok {
+			sched.reject(minute, d, "no minute is allowed after it in the hour")
+			d.Hour, d.Minute, d.Second = d.Hour+1, 0, 0
+			d.validateHour()
+			continue
+		} else if next != d.Minute {
+			sched.reject(minute, d, "minute is not allowed")
+			d.Minute, d.Second = next, 0
+		}
+
+		// update the second to the next allowed second, or the first second of next minute
+		if next, ok := sched.nextValue(second, d.Second); !ok {
+			sched.reject(second, d, "no second is allowed after it in the minute")
+			d.Minute, d.Second = d.Minute+1, 0
+			d.validateMinute()
+			continue
+		} else if next != d.Second {
+			sched.reject(second, d, "second is not allowed")
+			d.Second = next
+		}
+		return d, nil
 	}
+}
 
-	// set the next date of scheduler
-	updatedNext = time.Date(durNext.Year, time.Month(durNext.Month), durNext.date, durNext.Hour, durNext.Minute, durNext.Second, durNext.Nsec, durNext.location)
-	fmt.Println("updatedNext:", updatedNext, now(), durNext)
-	return
+// rewind moves the date backward to the last date at or before it at which every time unit matches the schedule.
+// It mirrors update: each time unit which does not match jumps straight to the previous allowed value, setting the
+// smaller time units to their last value, or underflows into the larger ones.
+func (d duration) rewind(sched *duration) (duration, error) {
+	var failed [calendars]bool
+	scanning, whole := d.Year, false
+	for {
+		// the year left behind was scanned from its end without any match.
+		if d.Year != scanning && whole {
+			failed[calendarOf(scanning)] = true
+		}
+
+		// update the year to the previous allowed year, skipping the years whose calendar is known not to match
+		prev, ok := sched.prevValue(year, d.Year)
+		for ok && failed[calendarOf(prev)] {
+			prev, ok = sched.prevValue(year, prev-1)
+		}
+		if !ok {
+			return d, fmt.Errorf("%w since year %d", ErrNoMatch, limits[year][0])
+		}
+		if prev != d.Year {
+			sched.reject(year, d, yearReason(failed[calendarOf(d.Year)]))
+			d.Year, d.Month, d.date, d.Hour, d.Minute, d.Second = prev, 12, 31, 23, 59, 59
+		}
+		if d.Year != scanning {
+			scanning, whole = d.Year, true
+		}
+
+		// update the month to the previous allowed month, or the last month of previous year
+		if prev, ok := sched.prevValue(month, d.Month); !ok {
+			sched.reject(month, d, "no month is allowed before it in the year")
+			d.Year, d.Month, d.date, d.Hour, d.Minute, d.Second = d.Year-1, 12, 31, 23, 59, 59
+			continue
+		} else if prev != d.Month {
+			sched.reject(month, d, "month is not allowed")
+			d.Month, d.Hour, d.Minute, d.Second = prev, 23, 59, 59
+			d.date = daysOfMonth(d.Month, d.Year, d.location)
+		}
+
+		// update the date to the previous day matching both date and weekday, or the last day of previous month
+		if !sched.matchDate(d.Year, d.Month, d.date) {
+			prev := sched.prevDateOf(d.Year, d.Month, d.date)
+			if prev < 1 {
+				sched.reject(date, d, "no date matching both date and weekday is left in the month")
+				d.date, d.Hour, d.Minute, d.Second = 0, 23, 59, 59
+				d.rewindDate()
+				continue
+			}
+			sched.reject(date, d, "date does not match both date and weekday")
+			d.date, d.Hour, d.Minute, d.Second = prev, 23, 59, 59
+		}
+
+		// update the hour to the previous allowed hour, or the last hour of previous day
+		if prev, ok := sched.prevValue(hour, d.Hour); !ok {
+			sched.reject(hour, d, "no hour is allowed before it in the day")
+			d.date, d.Hour, d.Minute, d.Second = d.date-1, 23, 59, 59
+			d.rewindDate()
+			continue
+		} else if prev != d.Hour {
+			sched.reject(hour, d, "hour is not allowed")
+			d.Hour, d.Minute, d.Second = prev, 59, 59
+		}
+
+		// update the minute to the previous allowed minute, or the last minute of previous hour
+		if prev, ok := sched.prevValue(minute, d.Minute); !ok {
+			sched.reject(minute, d, "no minute is allowed before it in the hour")
+			d.Hour, d.Minute, d.Second = d.Hour-1, 59, 59
+			d.rewindHour()
+			continue
+		} else if prev != d.Minute {
+			sched.reject(minute, d, "minute is not allowed")
+			d.Minute, d.Second = prev, 59
+		}
+
+		// update the second to the previous allowed second, or the last second of previous minute
+		if prev, ok := sched.prevValue(second, d.Second); !ok {
+			sched.reject(second, d, "no second is allowed before it in the minute")
+			d.Minute, d.Second = d.Minute-1, 59
+			d.rewindMinute()
+			continue
+		} else if prev != d.Second {
+			sched.reject(second, d, "second is not allowed")
+			d.Second = prev
+		}
+		return d, nil
+	}
 }
 
-// normalize corrects the time to avoid negative timestamp on next scheduler.
-func normalize(v int) int {
-	if v <= 0 {
-		return 1
+// yearReason returns why a year is rejected, depending on whether a year with the same calendar was found without any match.
+func yearReason(failed bool) string {
+	if failed {
+		return "no date matches in a year with the same calendar"
 	}
-	return v
+	return "year is not allowed"
 }
 
-// update updates the next date of scheduler based on the time unit.
-// It is used to update the next date of scheduler when the time unit is set to Every.
-// If updated time is in the past, it will update the next date of scheduler to next time unit.
-func (d duration) update(units timeUnit, next time.Time) (time.Time, duration, error) {
+// matchDate reports whether the given date matches both the date rules and the weekday rules of the schedule.
+// When the schedule is set to match either of them, a date matching any of the two is accepted.
+func (d *duration) matchDate(y, m, dt int) bool {
+	dim, first := monthOf(y, m, d.location)
+	return d.matchDateIn(y, m, dt, dim, first)
+}
 
-	var attemptsRem = 100
+// matchDateIn reports whether the given date matches the schedule, in a month of dim days whose first date falls on first.
+func (d *duration) matchDateIn(y, m, dt, dim, first int) bool {
+	wd := (first + dt - 1) % 7
+	matchDt, setDt := d.matchDateRules(y, m, dt, dim)
+	matchWd, setWd := d.matchDayRules(dt, wd, dim)
+	if d.dayOrDate && setDt && setWd {
+		return matchDt || matchWd
+	}
+	return matchDt && matchWd
+}
 
-reschedule: // reschedule reruns the logic until a valid time is found
-	attemptsRem--
+// matchDateRules reports whether the date matches the dates of the schedule, in a month of dim days.
+// set is false when no date is set, in which case every date matches.
+func (d *duration) matchDateRules(y, m, dt, dim int) (match, set bool) {
+	if vals, ok := d.sets[date]; ok {
+		set, match = true, contains(vals, dt)
+	} else if d.date > 0 {
+		// a single date beyond the end of the month runs on the last date of the month
+		last := d.date
+		if last > dim {
+			last = dim
+		}
+		set, match = true, dt == last
+	}
+	if d.lastDate {
+		set, match = true, match || dt == dim
+	}
+	for _, v := range d.nearestDates {
+		set, match = true, match || dt == nearestWeekday(y, m, v, dim)
+	}
+	return match || !set, set
+}
 
-	// if no more attempts are left, then return the error
-	if attemptsRem <= 0 {
-		return time.Time{}, d, fmt.Errorf("unable to find a valid upcoming date which can be scheduled matching given conditions")
+// nearestWeekday returns the weekday nearest to the given date within the month of dim days.
+// A date beyond the end of the month is moved to the last date first.
+// Saturdays move to the Friday before and Sundays to the Monday after,
+// unless it would leave the month, in which case they move to the Monday after or the Friday before.
+func nearestWeekday(y, m, dt, dim int) int {
+	if dt > dim {
+		dt = dim
 	}
+	switch time.Date(y, time.Month(m), dt, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if dt == 1 {
+			return dt + 2
+		}
+		return dt - 1
+	case time.Sunday:
+		if dt == dim {
+			return dt - 2
+		}
+		return dt + 1
+	}
+	return dt
+}
 
-	// set the next date of scheduler
-	next = time.Date(d.Year, time.Month(d.Month), d.date, d.Hour, d.Minute, d.Second, d.Nsec, d.location)
-	fmt.Printf("\tnext: %+v, %+v, %+v\n", next, now(), d)
-
-	// check if the next date is in the past
-	if now().After(next) {
-		switch units {
-		case year:
-			// update the year to next
-			d.Year++
-
-			// check overflow
-			d.validateYear()
-			goto reschedule
-
-		case month:
-			// update the month to next
-			d.Month++
-
-			// check overflow
-			d.validateMonth()
-			goto reschedule
-
-		case day:
-			// update the day to next
-			d.Day++
-			goto reschedule
-
-		case date:
-			// update the date to next
-			d.date++
-			d.Day++
-			if d.Day > 6 {
-				d.Day = 0
+// matchDayRules reports whether the date falling on weekday wd matches the weekdays of the schedule, in a month of dim days.
+// set is false when no weekday is set, in which case every date matches.
+func (d *duration) matchDayRules(dt, wd, dim int) (match, set bool) {
+	if vals := d.values(day); vals != nil {
+		set, match = true, contains(vals, wd)
+	}
+	for _, v := range d.lastDays {
+		set, match = true, match || (wd == v && dt+7 > dim)
+	}
+	for _, v := range d.nthDays {
+		set, match = true, match || (wd == v[0] && (dt-1)/7+1 == v[1])
+	}
+	return match || !set, set
+}
+
+// nextDateOf returns the next date of the month after the given date which matches the schedule.
+// If no more dates match within the month, the date overflowing into the next month is returned.
+// Only the dates set are looked at when nothing else can match, eg: a single one for February 29th on a Monday.
+func (d *duration) nextDateOf(y, m, dt int) int {
+	dim, first := monthOf(y, m, d.location)
+	vals := d.onlyDates()
+	for next := dt + 1; next <= dim; next++ {
+		if vals != nil {
+			if next = nextOf(vals, next); next > dim {
+				break
 			}
-			// check overflow
-			d.validateDate()
-			goto reschedule
+		}
+		if d.matchDateIn(y, m, next, dim, first) {
+			return next
+		}
+	}
+	return dim + 1
+}
 
-		case hour:
-			// update the hour to next
-			d.Hour++
+// prevDateOf returns the first date before the given date of the month which matches the schedule,
+// or 0 when no such date is left in the month.
+func (d *duration) prevDateOf(y, m, dt int) int {
+	dim, first := monthOf(y, m, d.location)
+	vals := d.onlyDates()
+	for prev := dt - 1; prev >= 1; prev-- {
+		if vals != nil {
+			if prev = prevOf(vals, prev); prev < 1 {
+				break
+			}
+		}
+		if d.matchDateIn(y, m, prev, dim, first) {
+			return prev
+		}
+	}
+	return 0
+}
 
-			// check overflow
-			d.validateHour()
-			goto reschedule
+// onlyDates returns the dates set when no other date can match the schedule, nil otherwise.
+func (d *duration) onlyDates() []int {
+	if d.dayOrDate || d.lastDate || len(d.nearestDates) > 0 {
+		return nil
+	}
+	return d.sets[date]
+}
 
-		case minute:
-			// update the minute to next
-			d.Minute++
+// nextOf returns the first value at or after v, or the largest int when there is none.
+func nextOf(vals []int, v int) int {
+	for _, val := range vals {
+		if val >= v {
+			return val
+		}
+	}
+	return math.MaxInt
+}
 
-			// check overflow
-			d.validateMinute()
-			goto reschedule
+// prevOf returns the last value at or before v, or the smallest int when there is none.
+func prevOf(vals []int, v int) int {
+	for i := len(vals) - 1; i >= 0; i-- {
+		if vals[i] <= v {
+			return vals[i]
+		}
+	}
+	return math.MinInt
+}
 
-		case second:
-			// update the second to next
-			d.Second++
+// monthOf returns the number of days of the given month of the year, and the weekday its first date falls on.
+func monthOf(y, m int, loc *time.Location) (dim, first int) {
+	return daysOfMonth(m, y, loc), weekdayOf(y, m, 1)
+}
 
-			// check overflow
-			d.validateSecond()
-			goto reschedule
+// monthOffsets are the shifts of the weekdays of the months, as counted by weekdayOf.
+var monthOffsets = [12]int{0, 3, 2, 5, 0, 3, 5, 1, 4, 6, 2, 4}
 
-		}
+// weekdayOf returns the weekday of the date of the Gregorian calendar, Sunday being 0, without going through time.Date
+// as it is called for every month scanned.
+func weekdayOf(y, m, dt int) int {
+	if m < int(March) {
+		y--
 	}
-	return next, d, nil
+	return (y + y/4 - y/100 + y/400 + monthOffsets[m-1] + dt) % 7
 }
 
-// daysOfMonth returns the number of days in the given month of the year.
-func daysOfMonth(month, year int, loc *time.Location) int {
-	return time.Date(year, time.Month(month+1), 0, 0, 0, 0, 0, loc).AddDate(0, 0, -1).Day()
+// isLeap reports whether the year has a February 29th.
+func isLeap(y int) bool {
+	return y%4 == 0 && (y%100 != 0 || y%400 == 0)
 }
 
-// validateYear validates the year of the given duration. If overflow is found, it will update the year to next.
-func (d *duration) validateYear() {
-	if d.Year > 9999 {
-		d.Year = now().Year()
-	}
+// daysOfMonth returns the number of days in the given month of the year.
+func daysOfMonth(month, year int, loc *time.Location) int {
+	return time.Date(year, time.Month(month+1), 0, 0, 0, 0, 0, loc).Day()
 }
 
 // validateMonth validates the month of the given duration. If overflow is found, it will update the year to next.
@@ -353,7 +1252,6 @@ func (d *duration) validateMonth() {
 	if d.Month > 12 {
 		d.Month = 1
 		d.Year++
-		d.validateYear()
 	}
 }
 
@@ -371,10 +1269,6 @@ func (d *duration) validateHour() {
 	if d.Hour > 23 {
 		d.Hour = 0
 		d.date++
-		d.Day++
-		if d.Day > 6 {
-			d.Day = 0
-		}
 		d.validateDate()
 	}
 }
@@ -388,45 +1282,83 @@ func (d *duration) validateMinute() {
 	}
 }
 
-// validateSecond validates the second of the given duration. If overflow is found, it will update the minute to next.
-func (d *duration) validateSecond() {
-	if d.Second > 59 {
-		d.Second = 0
-		d.Minute++
-		d.validateMinute()
+// rewindMonth checks the month of the given duration. If underflow is found, it will update the year to previous.
+func (d *duration) rewindMonth() {
+	if d.Month < 1 {
+		d.Month = 12
+		d.Year--
 	}
 }
 
-// validate validates the duration for time based scheduler.
-func (d *duration) validate() error {
-
-	// validate year
-	if d.Year > maxYear {
-		return fmt.Errorf("year must be between %d and %d, where -1 represents every year", Every, maxYear)
+// rewindDate checks the date of the given duration. If underflow is found, it will update the month to previous.
+func (d *duration) rewindDate() {
+	if d.date < 1 {
+		d.Month--
+		d.rewindMonth()
+		d.date = daysOfMonth(d.Month, d.Year, d.location)
 	}
-	// validate month
-	if d.Month > 12 {
-		return fmt.Errorf("month must be between 1 and 12 or schedule.Every which represents every month")
+}
+
+// rewindHour checks the hour of the given duration. If underflow is found, it will update the date to previous.
+func (d *duration) rewindHour() {
+	if d.Hour < 0 {
+		d.Hour = 23
+		d.date--
+		d.rewindDate()
 	}
-	// validate day
-	if d.date > 31 {
-		return fmt.Errorf("day must be between 1 and 31 or schedule.Every which represents every day")
+}
+
+// rewindMinute checks the minute of the given duration. If underflow is found, it will update the hour to previous.
+func (d *duration) rewindMinute() {
+	if d.Minute < 0 {
+		d.Minute = 59
+		d.Hour--
+		d.rewindHour()
 	}
-	// validate hour
-	if d.Hour > 23 {
-		return fmt.Errorf("hour must be between 0 and 23 or schedule.Every which represents every hour")
+}
+
+// validated returns a validated copy of the duration, leaving the duration itself untouched,
+// as it is shared by the copies of the Timer which may be scheduled concurrently.
+func (d *duration) validated() (*duration, error) {
+	c := *d
+	return &c, c.validate()
+}
+
+// validate validates the duration for time based scheduler.
+func (d *duration) validate() error {
+
+	// validate each time unit, left to every value by zero or Every
+	for _, f := range []struct {
+		units         timeUnit
+		val, min, max int
+	}{
+		{year, d.Year, 1, maxYear},
+		{month, d.Month, 1, 12},
+		{day, d.Day, 0, 6},
+		{date, d.date, 1, 31},
+		{hour, d.Hour, 0, 23},
+		{minute, d.Minute, 0, 59},
+		{second, d.Second, 0, 59},
+		{nsec, d.Nsec, 0, 999999999},
+	} {
+		if f.val != Every && (f.val < f.min && f.val != 0 || f.val > f.max) {
+			return &FieldError{Field: f.units.String(), Value: f.val, Min: f.min, Max: f.max}
+		}
 	}
-	// validate minute
-	if d.Minute > 59 {
-		return fmt.Errorf("minute must be between 0 and 59 or schedule.Every which represents every minute")
+	// validate the values set for each time unit
+	if d.err != nil {
+		return d.err
 	}
-	// validate second
-	if d.Second > 59 {
-		return fmt.Errorf("second must be between 0 and 59 or schedule.Every which represents every second")
+	if err := d.resolveHashes(); err != nil {
+		return err
 	}
-	// validate nanosecond
-	if d.Nsec > 999999999 {
-		return fmt.Errorf("nanosecond must be between 0 and 999999999 or schedule.Every which represents every nanosecond")
+	for _, units := range []timeUnit{year, month, day, date, hour, minute, second} {
+		lim := limits[units]
+		for _, v := range d.sets[units] {
+			if v < lim[0] || v > lim[1] {
+				return &FieldError{Field: units.String(), Value: v, Min: lim[0], Max: lim[1]}
+			}
+		}
 	}
 
 	// check if the duration is not set
@@ -451,7 +1383,17 @@ func (d *duration) validate() error {
 	if d.Second == 0 {
 		d.Second = Every
 	}
-	return nil
+
+	// check if the duration has any time unit set
+	if d.lastDate || len(d.nearestDates) > 0 || len(d.lastDays) > 0 || len(d.nthDays) > 0 {
+		return nil
+	}
+	for _, units := range []timeUnit{year, month, day, date, hour, minute, second} {
+		if d.values(units) != nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no timestamp is set, at least one time unit must be set other than schedule.Every", ErrInvalid)
 }
 
 // String returns the string representation of the duration.
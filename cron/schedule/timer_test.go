@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"context"
+	"math/rand"
 	"reflect"
 	"testing"
 	"time"
@@ -26,7 +27,8 @@ func TestByTimestamp(t *testing.T) {
 			want: &Timer{
 				schedule{
 					repeat:      true,
-					timer:       now(),
+					timer:       clk.Now(),
+					clock:       clk,
 					context:     ctx,
 					dur:         &duration{location: time.UTC},
 					schedEveryN: 0,
@@ -38,13 +40,40 @@ func TestByTimestamp(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ByTimestamp(tt.args.repeat, tt.args.ctx...); !reflect.DeepEqual(got, tt.want) {
+			if got := ByTimestamp(tt.args.repeat, tt.args.ctx...).SetClock(clk); !reflect.DeepEqual(&got, tt.want) {
 				t.Errorf("ByTimestamp() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestByTimestampWithClock(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	got := ByTimestampWithClock(true, c, ctx)
+	want := &Timer{
+		schedule{
+			repeat:  true,
+			timer:   c.Now(),
+			clock:   c,
+			context: ctx,
+			dur:     &duration{location: time.UTC},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ByTimestampWithClock() = %v, want %v", got, want)
+	}
+}
+
+func TestByTimestampWithClock_nilClock(t *testing.T) {
+	got := ByTimestampWithClock(true, nil)
+	if got.clock != systemClock {
+		t.Errorf("ByTimestampWithClock() clock = %v, want the system clock", got.clock)
+	}
+	if _, err := got.SetSeconds(0).Next(); err != nil {
+		t.Errorf("Timer.Next() error = %v", err)
+	}
+}
+
 func TestTimer_Next(t *testing.T) {
 	type fields struct {
 		schedule schedule
@@ -59,7 +88,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:      true,
-					timer:       now(),
+					timer:       clk.Now(),
+					clock:       clk,
 					context:     ctx,
 					dur:         &duration{location: time.UTC},
 					schedEveryN: 0,
@@ -73,7 +103,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Hour:     1,
@@ -93,7 +124,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -112,7 +144,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     Every,
@@ -135,7 +168,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     10000,
@@ -154,7 +188,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -173,7 +208,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -192,7 +228,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -212,7 +249,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -233,7 +271,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -255,7 +294,8 @@ func TestTimer_Next(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     2020,
@@ -312,7 +352,8 @@ func TestTimer_String(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Year:     1,
@@ -331,7 +372,8 @@ func TestTimer_String(t *testing.T) {
 			fields: fields{
 				schedule{
 					repeat:  true,
-					timer:   now(),
+					timer:   clk.Now(),
+					clock:   clk,
 					context: ctx,
 					dur: &duration{
 						Hour:     1,
@@ -359,3 +401,681 @@ func TestTimer_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTimer_SetClock(t *testing.T) {
+	t.Parallel()
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	tr := ByTimestamp(true).SetClock(c)
+	tr.SetYear(2021).SetMonth(June).SetDate(1).SetHour(13).SetNanosecond(500)
+
+	got, err := tr.Next()
+	if err != nil {
+		t.Fatalf("Timer.Next() error = %v", err)
+	}
+	if want := time.Date(2021, time.June, 1, 13, 0, 0, 500, time.UTC); !got.(*Timer).timer.Equal(want) {
+		t.Errorf("Timer.Next() = %v, want %v", got.(*Timer).timer, want)
+	}
+	if c.Waiters() != 1 {
+		t.Errorf("Timer.Next() did not wait on the clock of the scheduler")
+	}
+}
+
+func TestTimer_NextAfter(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC))
+	tr := ByTimestamp(true).SetClock(c).SetHours(13).SetMinutes(0).SetSeconds(0)
+
+	after := time.Date(2030, time.March, 1, 13, 0, 0, 0, time.UTC)
+	got, err := tr.NextAfter(after)
+	if err != nil {
+		t.Fatalf("Timer.NextAfter() error = %v", err)
+	}
+	if want := time.Date(2030, time.March, 2, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Timer.NextAfter() = %v, want %v", got, want)
+	}
+	if c.Waiters() != 0 || !tr.timer.Equal(c.Now()) {
+		t.Errorf("Timer.NextAfter() prepared the scheduler to run")
+	}
+}
+
+func TestTimer_Prev(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		before  time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "previous hour",
+			build:  func(t Timer) Timer { return t.SetMinutes(0).SetSeconds(0) },
+			before: time.Date(2020, time.January, 1, 10, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 1, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:   "previous year",
+			build:  func(t Timer) Timer { return t.SetMonths(January).SetDates(1).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			before: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:   "previous leap day",
+			build:  func(t Timer) Timer { return t.SetMonths(February).SetDates(29).SetHours(12).SetMinutes(0).SetSeconds(0) },
+			before: time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC),
+		}, {
+			name:   "last date of previous month",
+			build:  func(t Timer) Timer { return t.SetLastDate().SetHours(23).SetMinutes(30).SetSeconds(0) },
+			before: time.Date(2021, time.March, 30, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2021, time.February, 28, 23, 30, 0, 0, time.UTC),
+		}, {
+			name:   "previous week day",
+			build:  func(t Timer) Timer { return t.SetDayRange(Monday, Friday).SetHours(9).SetMinutes(0).SetSeconds(0) },
+			before: time.Date(2020, time.January, 6, 9, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 3, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:   "second tuesday",
+			build:  func(t Timer) Timer { return t.SetNthDay(Tuesday, 2).SetHours(3).SetMinutes(0).SetSeconds(0) },
+			before: time.Date(2020, time.February, 11, 3, 0, 0, 0, time.UTC),
+			want:   time.Date(2020, time.January, 14, 3, 0, 0, 0, time.UTC),
+		}, {
+			name:   "nanosecond",
+			build:  func(t Timer) Timer { return t.SetSeconds(30).SetNanosecond(500) },
+			before: time.Date(2020, time.January, 1, 0, 1, 30, 500, time.UTC),
+			want:   time.Date(2020, time.January, 1, 0, 0, 30, 500, time.UTC),
+		}, {
+			name:   "just after",
+			build:  func(t Timer) Timer { return t.SetSeconds(30).SetNanosecond(500) },
+			before: time.Date(2020, time.January, 1, 0, 1, 30, 501, time.UTC),
+			want:   time.Date(2020, time.January, 1, 0, 1, 30, 500, time.UTC),
+		}, {
+			name:    "before first year",
+			build:   func(t Timer) Timer { return t.SetYear(2020).SetMonth(June).SetDate(1) },
+			before:  time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build(ByTimestamp(true).SetClock(clk)).Prev(tt.before)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Timer.Prev() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Timer.Prev() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_setValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(t Timer) Timer
+		want  map[timeUnit][]int
+	}{
+		{
+			name:  "values are sorted and unique",
+			build: func(t Timer) Timer { return t.SetMinutes(45, 0, 15, 30, 15) },
+			want:  map[timeUnit][]int{minute: {0, 15, 30, 45}},
+		}, {
+			name:  "range",
+			build: func(t Timer) Timer { return t.SetHourRange(9, 17) },
+			want:  map[timeUnit][]int{hour: {9, 10, 11, 12, 13, 14, 15, 16, 17}},
+		}, {
+			name:  "range wraps around",
+			build: func(t Timer) Timer { return t.SetDayRange(Friday, Monday) },
+			want:  map[timeUnit][]int{day: {0, 1, 5, 6}},
+		}, {
+			name:  "step",
+			build: func(t Timer) Timer { return t.SetMonthStep(3).SetDateStep(10).SetSecondStep(20) },
+			want:  map[timeUnit][]int{month: {1, 4, 7, 10}, date: {1, 11, 21, 31}, second: {0, 20, 40}},
+		}, {
+			name:  "names",
+			build: func(t Timer) Timer { return t.SetMonths(July, January).SetDays(Sunday, Saturday) },
+			want:  map[timeUnit][]int{month: {1, 7}, day: {0, 6}},
+		}, {
+			name:  "single value replaces values",
+			build: func(t Timer) Timer { return t.SetMinutes(1, 2).SetMinute(3).SetHours(4).SetMinuteStep(30) },
+			want:  map[timeUnit][]int{hour: {4}, minute: {0, 30}},
+		}, {
+			name:  "no values allow every value",
+			build: func(t Timer) Timer { return t.SetSeconds(1).SetSeconds() },
+			want:  map[timeUnit][]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.build(ByTimestamp(true).SetClock(clk))
+			if !reflect.DeepEqual(got.dur.sets, tt.want) {
+				t.Errorf("Timer sets = %v, want %v", got.dur.sets, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_Next_values(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		wantErr bool
+	}{
+		{
+			name:    "value out of range",
+			build:   func(t Timer) Timer { return t.SetMinutes(0, 60) },
+			wantErr: true,
+		}, {
+			name:    "range out of range",
+			build:   func(t Timer) Timer { return t.SetHourRange(20, 24) },
+			wantErr: true,
+		}, {
+			name:    "invalid step",
+			build:   func(t Timer) Timer { return t.SetMinuteStep(0) },
+			wantErr: true,
+		}, {
+			name:    "invalid nth day",
+			build:   func(t Timer) Timer { return t.SetNthDay(Monday, 6) },
+			wantErr: true,
+		}, {
+			name:    "invalid last day",
+			build:   func(t Timer) Timer { return t.SetLastDay(Weekday(7)) },
+			wantErr: true,
+		}, {
+			name:    "invalid nearest weekday",
+			build:   func(t Timer) Timer { return t.SetNearestWeekday(32) },
+			wantErr: true,
+		}, {
+			name:    "nearest weekday alone",
+			build:   func(t Timer) Timer { return t.SetNearestWeekday(15) },
+			wantErr: false,
+		}, {
+			name:    "last date alone",
+			build:   func(t Timer) Timer { return t.SetLastDate() },
+			wantErr: false,
+		}, {
+			name:    "unknown gap policy",
+			build:   func(t Timer) Timer { return t.SetHours(0).SetGapPolicy(GapPolicy(2)) },
+			wantErr: true,
+		}, {
+			name:    "unknown overlap policy",
+			build:   func(t Timer) Timer { return t.SetHours(0).SetOverlapPolicy(OverlapPolicy(-1)) },
+			wantErr: true,
+		}, {
+			name:    "midnight",
+			build:   func(t Timer) Timer { return t.SetHours(0).SetMinutes(0).SetSeconds(0) },
+			wantErr: false,
+		}, {
+			name:    "never matches",
+			build:   func(t Timer) Timer { return t.SetMonths(February).SetDates(30) },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build(ByTimestamp(true).SetClock(clk)).Next()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Timer.Next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_duration_findNextDate(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("time.LoadLocation() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		build func(t Timer) Timer
+		now   time.Time
+		want  time.Time
+	}{
+		{
+			name: "next quarter hour",
+			build: func(t Timer) Timer {
+				return t.SetMinuteStep(15).SetSeconds(0).SetHourRange(9, 17).SetDayRange(Monday, Friday)
+			},
+			now:  time.Date(2020, time.January, 1, 9, 7, 0, 0, time.UTC),
+			want: time.Date(2020, time.January, 1, 9, 15, 0, 0, time.UTC),
+		}, {
+			name: "skips the weekend",
+			build: func(t Timer) Timer {
+				return t.SetMinuteStep(15).SetSeconds(0).SetHourRange(9, 17).SetDayRange(Monday, Friday)
+			},
+			now:  time.Date(2020, time.January, 3, 17, 45, 0, 0, time.UTC),
+			want: time.Date(2020, time.January, 6, 9, 0, 0, 0, time.UTC),
+		}, {
+			name:  "strictly after",
+			build: func(t Timer) Timer { return t.SetSeconds(30) },
+			now:   time.Date(2020, time.January, 1, 0, 0, 30, 0, time.UTC),
+			want:  time.Date(2020, time.January, 1, 0, 1, 30, 0, time.UTC),
+		}, {
+			name:  "nanosecond",
+			build: func(t Timer) Timer { return t.SetSeconds(30).SetNanosecond(500) },
+			now:   time.Date(2020, time.January, 1, 0, 0, 30, 400, time.UTC),
+			want:  time.Date(2020, time.January, 1, 0, 0, 30, 500, time.UTC),
+		}, {
+			name:  "31st",
+			build: func(t Timer) Timer { return t.SetDates(31).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "leap day",
+			build: func(t Timer) Timer { return t.SetMonths(February).SetDates(29).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "date and weekday",
+			build: func(t Timer) Timer {
+				return t.SetDates(13).SetDays(Friday).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			now:  time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.March, 13, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "date or weekday",
+			build: func(t Timer) Timer {
+				return t.SetDates(13).SetDays(Friday).SetDayOrDate(true).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			now:  time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.January, 3, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "wrapping hours",
+			build: func(t Timer) Timer { return t.SetHourRange(22, 2).SetMinutes(30).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 1, 3, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 1, 22, 30, 0, 0, time.UTC),
+		}, {
+			name:  "year end",
+			build: func(t Timer) Timer { return t.SetMonths(January).SetDates(1).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC),
+			want:  time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "last date of leap year february",
+			build: func(t Timer) Timer { return t.SetLastDate().SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "last date of february",
+			build: func(t Timer) Timer { return t.SetLastDate().SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2021, time.February, 28, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "first and last date",
+			build: func(t Timer) Timer { return t.SetDates(1).SetLastDate().SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2021, time.April, 30, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "single date beyond the end of month",
+			build: func(t Timer) Timer { return t.SetDate(31).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2021, time.April, 30, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "single date in leap year february",
+			build: func(t Timer) Timer { return t.SetDate(30).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "last friday",
+			build: func(t Timer) Timer { return t.SetLastDay(Friday).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "last friday of next month",
+			build: func(t Timer) Timer { return t.SetLastDay(Friday).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 28, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "nearest weekday of a saturday",
+			build: func(t Timer) Timer { return t.SetNearestWeekday(15).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 14, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "nearest weekday of a sunday",
+			build: func(t Timer) Timer { return t.SetNearestWeekday(15).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.March, 16, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "nearest weekday stays in the month",
+			build: func(t Timer) Timer { return t.SetNearestWeekday(1).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 3, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "last weekday of the month",
+			build: func(t Timer) Timer { return t.SetNearestWeekday(31).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.May, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "nearest weekday beyond the end of february",
+			build: func(t Timer) Timer { return t.SetNearestWeekday(30).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2021, time.February, 26, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "nearest weekday in a single month",
+			build: func(t Timer) Timer { return t.SetMonths(August).SetNearestWeekday(1).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2019, time.September, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.August, 3, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:  "second tuesday",
+			build: func(t Timer) Timer { return t.SetNthDay(Tuesday, 2).SetHours(3).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 14, 3, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.February, 11, 3, 0, 0, 0, time.UTC),
+		}, {
+			name:  "fifth monday",
+			build: func(t Timer) Timer { return t.SetNthDay(Monday, 5).SetHours(0).SetMinutes(0).SetSeconds(0) },
+			now:   time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want:  time.Date(2020, time.March, 30, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "location",
+			build: func(t Timer) Timer {
+				return t.SetHours(9).SetMinutes(0).SetSeconds(0).SetLocation(ny)
+			},
+			now:  time.Date(2020, time.January, 1, 15, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.January, 2, 9, 0, 0, 0, ny),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk))
+			if err := tr.dur.validate(); err != nil {
+				t.Fatalf("duration.validate() error = %v", err)
+			}
+			got, err := tr.dur.findNextDate(tt.now)
+			if err != nil {
+				t.Errorf("duration.findNextDate() error = %v", err)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("duration.findNextDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_duration_update(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		from    time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "leap day on a monday",
+			build: func(t Timer) Timer {
+				return t.SetMonths(February).SetDates(29).SetDays(Monday).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			from: time.Date(2016, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2044, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "fifth monday of february",
+			build: func(t Timer) Timer {
+				return t.SetMonths(February).SetNthDay(Monday, 5).SetHours(9).SetMinutes(30).SetSeconds(0)
+			},
+			from: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2044, time.February, 29, 9, 30, 0, 0, time.UTC),
+		}, {
+			name: "far future year",
+			build: func(t Timer) Timer {
+				return t.SetYear(9000).SetMonths(June).SetDates(15).SetHours(12).SetMinutes(0).SetSeconds(0)
+			},
+			from: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(9000, time.June, 15, 12, 0, 0, 0, time.UTC),
+		}, {
+			name: "last second of the last year",
+			build: func(t Timer) Timer {
+				return t.SetYear(maxYear).SetMonths(December).SetDates(31).SetHours(23).SetMinutes(59).SetSeconds(59)
+			},
+			from: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(maxYear, time.December, 31, 23, 59, 59, 0, time.UTC),
+		}, {
+			name: "friday the 13th of a far year",
+			build: func(t Timer) Timer {
+				return t.SetYear(5000).SetDates(13).SetDays(Friday).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			from: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(5000, time.June, 13, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "rolls every unit over",
+			build: func(t Timer) Timer {
+				return t.SetMonths(January).SetDates(1).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			from: time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC),
+			want: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "never matches",
+			build: func(t Timer) Timer {
+				return t.SetMonths(February).SetDates(30).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			from:    time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		}, {
+			name: "past the last year",
+			build: func(t Timer) Timer {
+				return t.SetYear(2019).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			from:    time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk))
+			if err := tr.dur.validate(); err != nil {
+				t.Fatalf("duration.validate() error = %v", err)
+			}
+			got, err := wallOf(tt.from).update(tr.dur)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("duration.update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.utc().Equal(tt.want) {
+				t.Errorf("duration.update() = %v, want %v", got.utc(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_duration_rewind(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t Timer) Timer
+		to      time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "leap day on a monday",
+			build: func(t Timer) Timer {
+				return t.SetMonths(February).SetDates(29).SetDays(Monday).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			to:   time.Date(2044, time.February, 28, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2016, time.February, 29, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "first second of the first year",
+			build: func(t Timer) Timer {
+				return t.SetYear(1).SetMonths(January).SetDates(1).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			to:   time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name: "rolls every unit back",
+			build: func(t Timer) Timer {
+				return t.SetMonths(December).SetDates(31).SetHours(23).SetMinutes(59).SetSeconds(59)
+			},
+			to:   time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC),
+		}, {
+			name: "never matches",
+			build: func(t Timer) Timer {
+				return t.SetMonths(April).SetDates(31).SetHours(0).SetMinutes(0).SetSeconds(0)
+			},
+			to:      time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := tt.build(ByTimestamp(true).SetClock(clk))
+			if err := tr.dur.validate(); err != nil {
+				t.Fatalf("duration.validate() error = %v", err)
+			}
+			got, err := wallOf(tt.to).rewind(tr.dur)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("duration.rewind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !got.utc().Equal(tt.want) {
+				t.Errorf("duration.rewind() = %v, want %v", got.utc(), tt.want)
+			}
+		})
+	}
+}
+
+// randomTimer returns a Timer with random values for each time unit, some of them left to every value.
+func randomTimer(r *rand.Rand) Timer {
+	pick := func(units timeUnit, n int) []int {
+		lim := limits[units]
+		vals := make([]int, 1+r.Intn(n))
+		for i := range vals {
+			vals[i] = lim[0] + r.Intn(lim[1]-lim[0]+1)
+		}
+		return vals
+	}
+
+	tr := ByTimestamp(true).SetClock(clk)
+	if r.Intn(4) == 0 {
+		years := make([]int, 1+r.Intn(3))
+		for i := range years {
+			years[i] = 2000 + r.Intn(60)
+		}
+		tr.setValues(year, years)
+	}
+	for _, units := range []timeUnit{month, date, day, hour, minute, second} {
+		if r.Intn(2) == 0 {
+			tr.setValues(units, pick(units, 4))
+		}
+	}
+	switch r.Intn(8) {
+	case 0:
+		tr = tr.SetLastDate()
+	case 1:
+		tr = tr.SetNthDay(Weekday(r.Intn(7)), 1+r.Intn(5))
+	case 2:
+		tr = tr.SetLastDay(Weekday(r.Intn(7)))
+	case 3:
+		tr = tr.SetDayOrDate(true)
+	}
+	if tr.dur.validate() != nil {
+		// every time unit was left to every value.
+		tr.setValues(second, pick(second, 4))
+	}
+	return tr
+}
+
+// scanWall returns the first wall time matching the schedule from the given one within the given number of days,
+// looking at each of them in turn, backward when step is negative.
+func scanWall(d *duration, from duration, days, step int) (duration, bool) {
+	allowed := func(units timeUnit, val int) bool {
+		vals := d.values(units)
+		return vals == nil || contains(vals, val)
+	}
+	clock := func(h, m, s int) int { return h*3600 + m*60 + s }
+
+	day := from.utc()
+	for i := 0; i < days; i, day = i+1, day.AddDate(0, 0, step) {
+		y, m, dt := day.Date()
+		if y < limits[year][0] || y > maxYear {
+			break
+		}
+		if !allowed(year, y) || !allowed(month, int(m)) || !d.matchDate(y, int(m), dt) {
+			continue
+		}
+
+		// the first and last clock times of the day which can match, the whole day but for the first one.
+		lo, hi := 0, clock(23, 59, 59)
+		if i == 0 && step > 0 {
+			lo = clock(from.Hour, from.Minute, from.Second)
+		} else if i == 0 {
+			hi = clock(from.Hour, from.Minute, from.Second)
+		}
+
+		// the clock times of the day in the order of the scan.
+		for c := lo; c <= hi; c++ {
+			at := c
+			if step < 0 {
+				at = lo + hi - c
+			}
+			if allowed(hour, at/3600) && allowed(minute, at/60%60) && allowed(second, at%60) {
+				return wallOf(time.Date(y, m, dt, at/3600, at/60%60, at%60, 0, time.UTC)), true
+			}
+		}
+	}
+	return duration{}, false
+}
+
+func Test_duration_update_exhaustive(t *testing.T) {
+	const days = 3 * 366
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		tr := randomTimer(r)
+		if err := tr.dur.validate(); err != nil {
+			t.Fatalf("duration.validate() error = %v", err)
+		}
+		for j := 0; j < 5; j++ {
+			from := wallOf(time.Date(2000+r.Intn(60), time.Month(1+r.Intn(12)), 1+r.Intn(28), r.Intn(24), r.Intn(60), r.Intn(60), 0, time.UTC))
+			want, ok := scanWall(tr.dur, from, days, 1)
+			got, err := from.update(tr.dur)
+			switch {
+			case ok && err != nil:
+				t.Errorf("%v: duration.update(%v) error = %v, want %v", tr.String(), from.utc(), err, want.utc())
+			case ok && !got.utc().Equal(want.utc()):
+				t.Errorf("%v: duration.update(%v) = %v, want %v", tr.String(), from.utc(), got.utc(), want.utc())
+			case !ok && err == nil && got.utc().Before(from.utc().AddDate(0, 0, days-1)):
+				t.Errorf("%v: duration.update(%v) = %v, want none within %d days", tr.String(), from.utc(), got.utc(), days)
+			}
+
+			want, ok = scanWall(tr.dur, from, days, -1)
+			got, err = from.rewind(tr.dur)
+			switch {
+			case ok && err != nil:
+				t.Errorf("%v: duration.rewind(%v) error = %v, want %v", tr.String(), from.utc(), err, want.utc())
+			case ok && !got.utc().Equal(want.utc()):
+				t.Errorf("%v: duration.rewind(%v) = %v, want %v", tr.String(), from.utc(), got.utc(), want.utc())
+			case !ok && err == nil && got.utc().After(from.utc().AddDate(0, 0, 1-days)):
+				t.Errorf("%v: duration.rewind(%v) = %v, want none within %d days", tr.String(), from.utc(), got.utc(), days)
+			}
+		}
+	}
+}
+
+func Test_weekdayOf(t *testing.T) {
+	for y := 1; y <= maxYear; y += 7 {
+		for m := 1; m <= 12; m++ {
+			want := int(time.Date(y, time.Month(m), 1, 0, 0, 0, 0, time.UTC).Weekday())
+			if got := weekdayOf(y, m, 1); got != want {
+				t.Fatalf("weekdayOf(%d, %d, 1) = %v, want %v", y, m, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkTimer_NextAfter(b *testing.B) {
+	after := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+	benchmarks := []struct {
+		name  string
+		timer Timer
+	}{
+		{name: "next second", timer: ByTimestamp(true).SetSeconds(1)},
+		{name: "next day", timer: ByTimestamp(true).SetHours(9).SetMinutes(0).SetSeconds(0)},
+		{name: "leap day on a monday", timer: ByTimestamp(true).SetMonths(February).SetDates(29).SetDays(Monday).SetHours(0).SetMinutes(0).SetSeconds(0)},
+		{name: "far future year", timer: ByTimestamp(true).SetYear(9000).SetHours(0).SetMinutes(0).SetSeconds(0)},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := bm.timer.NextAfter(after); err != nil {
+					b.Fatalf("Timer.NextAfter() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package schedule
+
+import (
+	"context"
+	"time"
+)
+
+// Wait blocks until the next run of the scheduler from the current time of its clock, and returns the time of the run.
+// It returns early with the error of the context when either ctx or the context of the scheduler is cancelled.
+//
+// eg:
+//	...
+//	t := schedule.ByTimestamp(true).SetMinutes(0).SetSeconds(0)
+//	at, err := t.Wait(ctx)   // will block until the top of the next hour.
+//	...
+func (t Timer) Wait(ctx context.Context) (time.Time, error) {
+	_, at, err := t.waitRun(ctx, time.Time{}, t.NextAfter, t.arm)
+	return at, err
+}
+
+// C returns a channel on which the time of each run of the scheduler is sent as it comes.
+// The scheduler re-arms itself after each run when it repeats, and the channel is closed once it has no run left,
+// or once either ctx or the context of the scheduler is cancelled. The runs are sent by a goroutine owned by the caller,
+// which cancels ctx to release it once the channel is no longer read.
+//
+// eg:
+//	...
+//	t := schedule.ByTimestamp(true).SetMinutes(0).SetSeconds(0)
+//	for at := range t.C(ctx) {
+//		...   // will run at the top of every hour, until ctx is cancelled.
+//	}
+//	...
+func (t Timer) C(ctx context.Context) <-chan time.Time {
+	return t.fire(ctx, t.NextAfter, t.arm)
+}
+
+// Wait blocks until the next run of the scheduler from the current time of its clock, and returns the time of the run.
+// It returns early with the error of the context when either ctx or the context of the scheduler is cancelled.
+// A scheduler keeping to a fixed rate keeps to its ticks from one call to the next, as it is armed in place.
+//
+// eg:
+//	...
+//	i := schedule.ByFreq(true).AddMinute(5).SetMode(schedule.FixedRate)
+//	for {
+//		at, err := i.Wait(ctx)   // will block until the next tick, 5 minutes after the previous one.
+//		...
+//	}
+//	...
+func (i *Interval) Wait(ctx context.Context) (time.Time, error) {
+	_, at, err := i.waitRun(ctx, i.due, i.nextAfter, i.arm)
+	return at, err
+}
+
+// C returns a channel on which the time of each run of the scheduler is sent as it comes.
+// The scheduler re-arms itself after each run when it repeats, and the channel is closed once it has no run left,
+// or once either ctx or the context of the scheduler is cancelled. The runs are sent by a goroutine owned by the caller,
+// which cancels ctx to release it once the channel is no longer read. A scheduler keeping to a fixed rate runs the ticks
+// missed while the channel was not read as per its missed policy, as Next does.
+//
+// eg:
+//	...
+//	i := schedule.ByFreq(true).AddMinute(5)
+//	for at := range i.C(ctx) {
+//		...   // will run every 5 minutes, until ctx is cancelled.
+//	}
+//	...
+func (i Interval) C(ctx context.Context) <-chan time.Time {
+	return i.fire(ctx, i.nextAfter, i.arm)
+}
+
+// nextAfter returns the first run after t as NextAfter does, reading the anchor the scheduler has once armed.
+func (i *Interval) nextAfter(t time.Time) (time.Time, error) {
+	return i.NextAfter(t)
+}
+
+// arm returns the time the run found at next from now is due at, and the time it runs at once spread by the jitter.
+func (s *schedule) arm(now, next time.Time) (due, at time.Time) {
+	return next, s.spread(now, next)
+}
+
+// done returns the channel closed when the context of the scheduler is cancelled, nil when it has none.
+func (s *schedule) done() <-chan struct{} {
+	if s.context == nil {
+		return nil
+	}
+	return s.context.Done()
+}
+
+// waitRun blocks until the first run found by nextAfter after both after and the current time of the clock.
+// It returns the time the run is due at, and the time it runs at, both as given by arm.
+func (s *schedule) waitRun(ctx context.Context, after time.Time, nextAfter func(time.Time) (time.Time, error),
+	arm func(now, next time.Time) (due, at time.Time)) (due, at time.Time, err error) {
+	clock := s.timeSource()
+	now := clock.Now()
+	if after.Before(now) {
+		after = now
+	}
+	next, err := nextAfter(after)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if err = s.countRun(); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	due, at = arm(now, next)
+
+	timer := clock.NewTimer(at.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return due, at, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-s.done():
+		err = s.context.Err()
+	}
+	// the run was cancelled before it came, so it is not counted.
+	s.uncountRun()
+	return time.Time{}, time.Time{}, err
+}
+
+// fire sends the time of each run found by nextAfter on the returned channel, until the scheduler stops or ctx is cancelled.
+func (s *schedule) fire(ctx context.Context, nextAfter func(time.Time) (time.Time, error),
+	arm func(now, next time.Time) (due, at time.Time)) <-chan time.Time {
+	c := make(chan time.Time, 1)
+	go func() {
+		defer close(c)
+
+		// each run is found after the previous one was due, so a run spread early by the jitter does not come twice.
+		var due, at time.Time
+		var err error
+		for {
+			if due, at, err = s.waitRun(ctx, due, nextAfter, arm); err != nil {
+				return
+			}
+			select {
+			case c <- at:
+			case <-ctx.Done():
+				return
+			case <-s.done():
+				return
+			}
+			if !s.repeat {
+				return
+			}
+		}
+	}()
+	return c
+}
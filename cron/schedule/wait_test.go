@@ -0,0 +1,361 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// advance moves the clock forward by d once a scheduler waits on it.
+func advance(t *testing.T, c *FakeClock, d time.Duration) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); c.Waiters() == 0; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("FakeClock.Waiters() = 0, want a scheduler waiting")
+		}
+	}
+	c.Advance(d)
+}
+
+// collect advances the clock by d for each run the channel is expected to send, and returns the runs sent
+// until the channel is closed.
+func collect(t *testing.T, c *FakeClock, d time.Duration, runs <-chan time.Time, want int) []time.Time {
+	t.Helper()
+	var got []time.Time
+	for i := 0; i < want; i++ {
+		advance(t, c, d)
+		select {
+		case at, ok := <-runs:
+			if !ok {
+				return got
+			}
+			got = append(got, at)
+		case <-time.After(time.Second):
+			t.Fatalf("C() sent %d runs, want %d", len(got), want)
+		}
+	}
+	select {
+	case at, ok := <-runs:
+		if ok {
+			t.Errorf("C() sent %v, want no run after %d runs", at, want)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("C() was not closed after %d runs", want)
+	}
+	return got
+}
+
+func TestTimer_Wait(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		sched   func(c *FakeClock) Timer
+		advance time.Duration
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "top of the hour",
+			sched: func(c *FakeClock) Timer {
+				return ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0)
+			},
+			advance: 30 * time.Minute,
+			want:    time.Date(2020, time.January, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "exhausted",
+			sched: func(c *FakeClock) Timer {
+				return ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).EndAt(start)
+			},
+			wantErr: ErrScheduleExhausted,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewFakeClock(start)
+			tr := tt.sched(c)
+			if tt.wantErr != nil {
+				if _, err := tr.Wait(context.Background()); !errors.Is(err, tt.wantErr) {
+					t.Errorf("Timer.Wait() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			done := make(chan time.Time)
+			go func() {
+				at, err := tr.Wait(context.Background())
+				if err != nil {
+					t.Errorf("Timer.Wait() error = %v", err)
+				}
+				done <- at
+			}()
+			advance(t, c, tt.advance)
+			if got := <-done; !got.Equal(tt.want) {
+				t.Errorf("Timer.Wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimer_Wait_cancel(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	tr := ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).MaxRuns(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := tr.Wait(ctx)
+		done <- err
+	}()
+	advance(t, c, 0)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Timer.Wait() error = %v, want %v", err, context.Canceled)
+	}
+	if got := c.Waiters(); got != 0 {
+		t.Errorf("FakeClock.Waiters() = %v, want 0", got)
+	}
+
+	// the cancelled run is not counted.
+	go func() {
+		_, err := tr.Wait(context.Background())
+		done <- err
+	}()
+	advance(t, c, 30*time.Minute)
+	if err := <-done; err != nil {
+		t.Errorf("Timer.Wait() error = %v, want nil", err)
+	}
+}
+
+func TestTimer_C(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		sched func(c *FakeClock) Timer
+		want  []time.Time
+	}{
+		{
+			name: "repeat",
+			sched: func(c *FakeClock) Timer {
+				return ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).MaxRuns(3)
+			},
+			want: []time.Time{
+				time.Date(2020, time.January, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2020, time.January, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "once",
+			sched: func(c *FakeClock) Timer {
+				return ByTimestamp(false).SetClock(c).SetMinutes(0).SetSeconds(0)
+			},
+			want: []time.Time{
+				time.Date(2020, time.January, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "until the end",
+			sched: func(c *FakeClock) Timer {
+				return ByTimestamp(true).SetClock(c).SetMinutes(0).SetSeconds(0).EndAt(start.Add(2 * time.Hour))
+			},
+			want: []time.Time{
+				time.Date(2020, time.January, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewFakeClock(start)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			got := collect(t, c, time.Hour, tt.sched(c).C(ctx), len(tt.want))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Timer.C() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Timer.C() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestInterval_Wait(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	i := ByFreq(true).SetClock(c).AddMinute(5)
+
+	done := make(chan time.Time)
+	go func() {
+		at, err := i.Wait(context.Background())
+		if err != nil {
+			t.Errorf("Interval.Wait() error = %v", err)
+		}
+		done <- at
+	}()
+	advance(t, c, 5*time.Minute)
+	if got, want := <-done, start.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("Interval.Wait() = %v, want %v", got, want)
+	}
+}
+
+func TestInterval_C(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := ByFreq(true).SetClock(c).AddMinute(5).C(ctx)
+
+	for n := 1; n <= 3; n++ {
+		advance(t, c, 5*time.Minute)
+		if got, want := <-runs, start.Add(time.Duration(n)*5*time.Minute); !got.Equal(want) {
+			t.Errorf("Interval.C() = %v, want %v", got, want)
+		}
+	}
+
+	// cancelling the context closes the channel, releasing the goroutine sending the runs.
+	cancel()
+	select {
+	case at, ok := <-runs:
+		if ok {
+			t.Errorf("Interval.C() = %v, want closed channel", at)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Interval.C() was not closed once the context was cancelled")
+	}
+}
+
+func TestInterval_C_schedulerContext(t *testing.T) {
+	t.Parallel()
+	c := NewFakeClock(time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := ByFreq(true, ctx).SetClock(c).AddMinute(5).C(context.Background())
+
+	// cancelling the context of the scheduler closes the channel as well.
+	advance(t, c, 0)
+	cancel()
+	select {
+	case at, ok := <-runs:
+		if ok {
+			t.Errorf("Interval.C() = %v, want closed channel", at)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Interval.C() was not closed once the context of the scheduler was cancelled")
+	}
+}
+
+func TestInterval_Wait_fixedRate(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	i := ByFreq(true).SetClock(c).AddMinute(5).SetMode(FixedRate)
+
+	wait := func(d time.Duration) time.Time {
+		t.Helper()
+		done := make(chan time.Time)
+		go func() {
+			at, err := i.Wait(context.Background())
+			if err != nil {
+				t.Errorf("Interval.Wait() error = %v", err)
+			}
+			done <- at
+		}()
+		advance(t, c, d)
+		select {
+		case at := <-done:
+			return at
+		case <-time.After(time.Second):
+			t.Fatalf("Interval.Wait() did not return at %v", c.Now())
+		}
+		return time.Time{}
+	}
+	if got, want := wait(5*time.Minute), start.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("Interval.Wait() = %v, want %v", got, want)
+	}
+
+	// the run takes 2 minutes, the next one still comes on the tick 5 minutes after the previous one.
+	c.Advance(2 * time.Minute)
+	if got, want := wait(3*time.Minute), start.Add(10*time.Minute); !got.Equal(want) {
+		t.Errorf("Interval.Wait() = %v, want %v", got, want)
+	}
+}
+
+func TestInterval_C_fixedRate(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2020, time.January, 1, 10, 30, 0, 0, time.UTC)
+	at := func(min int) time.Time { return start.Add(time.Duration(min) * time.Minute) }
+	tests := []struct {
+		name     string
+		missed   MissedPolicy
+		caughtUp []time.Time // runs sent right away for the missed ticks.
+	}{
+		{
+			name:   "skip",
+			missed: MissedSkip,
+		}, {
+			name:     "fire once",
+			missed:   MissedFireOnce,
+			caughtUp: []time.Time{at(22)},
+		}, {
+			name:     "fire all",
+			missed:   MissedFireAll,
+			caughtUp: []time.Time{at(22), at(22)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := NewFakeClock(start)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			runs := ByFreq(true).SetClock(c).AddMinute(5).SetMode(FixedRate).SetMissedPolicy(tt.missed).C(ctx)
+			receive := func() time.Time {
+				t.Helper()
+				select {
+				case run := <-runs:
+					return run
+				case <-time.After(time.Second):
+					t.Fatalf("Interval.C() sent no run")
+				}
+				return time.Time{}
+			}
+
+			// the consumer is slow: the ticks at 10:45 and 10:50 are missed while the run at 10:40 is not read.
+			advance(t, c, 5*time.Minute)
+			advance(t, c, 5*time.Minute)
+			c.Advance(12 * time.Minute)
+
+			want := append([]time.Time{at(5), at(10)}, tt.caughtUp...)
+			var got []time.Time
+			for range want {
+				got = append(got, receive())
+			}
+
+			// the missed ticks are run, the scheduler waits for the tick at 10:55.
+			want = append(want, at(25))
+			advance(t, c, 3*time.Minute)
+			got = append(got, receive())
+			for i := range got {
+				if !got[i].Equal(want[i]) {
+					t.Errorf("Interval.C() = %v, want %v", got, want)
+					break
+				}
+			}
+		})
+	}
+}